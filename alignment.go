@@ -0,0 +1,239 @@
+package fuzzy
+
+// AlignmentScoring holds the scores used by SmithWaterman and
+// NeedlemanWunsch. Gap penalties are affine: a gap of k runes
+// costs GapOpen + (k-1)*GapExtend, so a single long gap, such as
+// a missing word, costs less than many scattered ones.
+type AlignmentScoring struct {
+	Match     int // added for each pair of equal runes, positive
+	Mismatch  int // added for each pair of different runes, usually negative
+	GapOpen   int // subtracted for the first rune of a gap
+	GapExtend int // subtracted for each further rune of a gap
+}
+
+// DefaultAlignmentScoring is the scoring used by SmithWatermanRatio
+// and NeedlemanWunschRatio.
+var DefaultAlignmentScoring = AlignmentScoring{Match: 2, Mismatch: -1, GapOpen: 2, GapExtend: 1}
+
+// AlignmentGap is the rune standing for a gap in the aligned strings
+// of an Alignment.
+const AlignmentGap = '-'
+
+// Alignment is an alignment of two strings. The aligned spans are
+// [Start1,End1) in the first string and [Start2,End2) in the second,
+// in runes. Aligned1 and Aligned2 hold the spans with AlignmentGap
+// inserted for gaps, so that they have the same length and aligned
+// runes are at the same positions.
+type Alignment struct {
+	Score        int
+	Start1, End1 int
+	Start2, End2 int
+	Aligned1     string
+	Aligned2     string
+}
+
+// SmithWaterman computes the best local alignment of two strings with the
+// Smith-Waterman algorithm, using Gotoh's method for affine gaps: the pair
+// of substrings aligning with the highest score. Unlike PartialRatio, the
+// aligned substrings may have different lengths, so a damaged word with
+// missing or extra characters is found inside a longer string.
+// The alignment is empty and has a score of 0 if no runes match.
+func SmithWaterman(s1, s2 string, scoring AlignmentScoring) *Alignment {
+	return align([]rune(s1), []rune(s2), scoring, true)
+}
+
+// NeedlemanWunsch computes the best global alignment of two strings with
+// the Needleman-Wunsch algorithm, using Gotoh's method for affine gaps:
+// both strings are aligned from beginning to end.
+func NeedlemanWunsch(s1, s2 string, scoring AlignmentScoring) *Alignment {
+	return align([]rune(s1), []rune(s2), scoring, false)
+}
+
+// SmithWatermanRatio computes a score of how well the shorter string
+// is found inside the longer one: the score of their best local
+// alignment under DefaultAlignmentScoring, relative to the score of
+// the shorter string aligned with itself.
+// Returns an integer score [0,100].
+func SmithWatermanRatio(s1, s2 string) int {
+	chrs1, chrs2 := []rune(s1), []rune(s2)
+	best := DefaultAlignmentScoring.Match * min(len(chrs1), len(chrs2))
+	if best <= 0 {
+		return 0
+	}
+	a := align(chrs1, chrs2, DefaultAlignmentScoring, true)
+	return similarityScore(float64(a.Score) / float64(best))
+}
+
+// NeedlemanWunschRatio computes a score of how close two strings are
+// from the score of their best global alignment under
+// DefaultAlignmentScoring, relative to the score of the longer
+// string aligned with itself. Negative scores count as 0.
+// Returns an integer score [0,100].
+func NeedlemanWunschRatio(s1, s2 string) int {
+	chrs1, chrs2 := []rune(s1), []rune(s2)
+	best := DefaultAlignmentScoring.Match * len(chrs1)
+	if len(chrs2) > len(chrs1) {
+		best = DefaultAlignmentScoring.Match * len(chrs2)
+	}
+	if best <= 0 {
+		return 0
+	}
+	a := align(chrs1, chrs2, DefaultAlignmentScoring, false)
+	return similarityScore(float64(a.Score) / float64(best))
+}
+
+// alignment states: the last runes are paired, or the last rune
+// of the first or the second string is aligned with a gap
+const (
+	alignPair = iota
+	alignGap2
+	alignGap1
+)
+
+// alignNone marks states that cannot be reached.
+const alignNone = -(1 << 30)
+
+func align(chrs1, chrs2 []rune, scoring AlignmentScoring, local bool) *Alignment {
+	n, m := len(chrs1), len(chrs2)
+	// score[state][i][j] is the best score of an alignment of
+	// chrs1[:i] and chrs2[:j] ending in the given state
+	var score [3][][]int
+	for state := range score {
+		score[state] = make([][]int, n+1)
+		for i := range score[state] {
+			score[state][i] = make([]int, m+1)
+			for j := range score[state][i] {
+				score[state][i][j] = alignNone
+			}
+		}
+	}
+	pairScore := func(i, j int) int {
+		if chrs1[i-1] == chrs2[j-1] {
+			return scoring.Match
+		}
+		return scoring.Mismatch
+	}
+	best := func(i, j int) int {
+		return maxInt(score[alignPair][i][j], score[alignGap2][i][j], score[alignGap1][i][j])
+	}
+
+	score[alignPair][0][0] = 0
+	if !local {
+		for i := 1; i <= n; i++ {
+			score[alignGap2][i][0] = -scoring.GapOpen - (i-1)*scoring.GapExtend
+		}
+		for j := 1; j <= m; j++ {
+			score[alignGap1][0][j] = -scoring.GapOpen - (j-1)*scoring.GapExtend
+		}
+	}
+
+	bestScore, bestI, bestJ := 0, 0, 0
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= m; j++ {
+			prev := best(i-1, j-1)
+			if local && prev < 0 {
+				// a local alignment may start anywhere
+				prev = 0
+			}
+			score[alignPair][i][j] = prev + pairScore(i, j)
+			score[alignGap2][i][j] = maxInt(
+				score[alignPair][i-1][j]-scoring.GapOpen,
+				score[alignGap2][i-1][j]-scoring.GapExtend,
+				score[alignGap1][i-1][j]-scoring.GapOpen)
+			score[alignGap1][i][j] = maxInt(
+				score[alignPair][i][j-1]-scoring.GapOpen,
+				score[alignGap1][i][j-1]-scoring.GapExtend,
+				score[alignGap2][i][j-1]-scoring.GapOpen)
+			if local && score[alignPair][i][j] > bestScore {
+				bestScore, bestI, bestJ = score[alignPair][i][j], i, j
+			}
+		}
+	}
+	if !local {
+		bestScore, bestI, bestJ = best(n, m), n, m
+	}
+
+	// trace the alignment back from its end
+	var aligned1, aligned2 []rune
+	i, j := bestI, bestJ
+	state := alignPair
+	if !local {
+		switch bestScore {
+		case score[alignGap2][n][m]:
+			state = alignGap2
+		case score[alignGap1][n][m]:
+			state = alignGap1
+		}
+	}
+	traced := local && bestScore == 0
+	for !traced && (i > 0 || j > 0) {
+		cur := score[state][i][j]
+		switch state {
+		case alignPair:
+			aligned1 = append(aligned1, chrs1[i-1])
+			aligned2 = append(aligned2, chrs2[j-1])
+			prev := cur - pairScore(i, j)
+			i, j = i-1, j-1
+			if local && prev == 0 {
+				// the alignment starts here
+				traced = true
+				break
+			}
+			state = previousAlignState(score, i, j, prev, 0, 0, 0)
+		case alignGap2:
+			aligned1 = append(aligned1, chrs1[i-1])
+			aligned2 = append(aligned2, AlignmentGap)
+			i--
+			state = previousAlignState(score, i, j, cur, scoring.GapOpen, scoring.GapExtend, scoring.GapOpen)
+		case alignGap1:
+			aligned1 = append(aligned1, AlignmentGap)
+			aligned2 = append(aligned2, chrs2[j-1])
+			j--
+			state = previousAlignState(score, i, j, cur, scoring.GapOpen, scoring.GapOpen, scoring.GapExtend)
+		}
+	}
+	reverseRunes(aligned1)
+	reverseRunes(aligned2)
+	return &Alignment{
+		Score:    bestScore,
+		Start1:   i,
+		End1:     bestI,
+		Start2:   j,
+		End2:     bestJ,
+		Aligned1: string(aligned1),
+		Aligned2: string(aligned2),
+	}
+}
+
+// previousAlignState returns the state at (i,j) from which a score of
+// cur was reached, given the penalty for leaving each state.
+func previousAlignState(score [3][][]int, i, j, cur, fromPair, fromGap2, fromGap1 int) int {
+	switch cur {
+	case score[alignPair][i][j] - fromPair:
+		return alignPair
+	case score[alignGap2][i][j] - fromGap2:
+		return alignGap2
+	}
+	return alignGap1
+}
+
+func maxInt(values ...int) int {
+	best := values[0]
+	for _, v := range values[1:] {
+		if v > best {
+			best = v
+		}
+	}
+	return best
+}
+
+func reverseRunes(chrs []rune) {
+	for i, j := 0, len(chrs)-1; i < j; i, j = i+1, j-1 {
+		chrs[i], chrs[j] = chrs[j], chrs[i]
+	}
+}
+
+// String formats the alignment as its two aligned strings, one per line.
+func (a *Alignment) String() string {
+	return a.Aligned1 + "\n" + a.Aligned2
+}
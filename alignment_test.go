@@ -0,0 +1,138 @@
+package fuzzy
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestSmithWaterman(t *testing.T) {
+	cases := []struct {
+		s1, s2                     string
+		score                      int
+		start1, end1, start2, end2 int
+		aligned1, aligned2         string
+	}{
+		{"ACACACTA", "AGCACACA", 10, 0, 5, 3, 8, "ACACA", "ACACA"},
+		{"Widget Pro", "2x Widgt Pro 12.50", 16, 0, 10, 3, 12, "Widget Pro", "Widg-t Pro"},
+		{"abc", "xyz", 0, 0, 0, 0, 0, "", ""},
+		{"", "abc", 0, 0, 0, 0, 0, "", ""},
+	}
+	for _, c := range cases {
+		a := SmithWaterman(c.s1, c.s2, DefaultAlignmentScoring)
+		if a.Score != c.score || a.Start1 != c.start1 || a.End1 != c.end1 || a.Start2 != c.start2 || a.End2 != c.end2 ||
+			a.Aligned1 != c.aligned1 || a.Aligned2 != c.aligned2 {
+			t.Errorf("SmithWaterman(%q, %q): Expected %v [%v,%v) [%v,%v)\n%v\n%v\ngot %v [%v,%v) [%v,%v)\n%v",
+				c.s1, c.s2, c.score, c.start1, c.end1, c.start2, c.end2, c.aligned1, c.aligned2,
+				a.Score, a.Start1, a.End1, a.Start2, a.End2, a)
+		}
+	}
+}
+
+func TestNeedlemanWunsch(t *testing.T) {
+	cases := []struct {
+		s1, s2             string
+		score              int
+		aligned1, aligned2 string
+	}{
+		{"GATTACA", "GCATGCU", 0, "G-ATTACA", "GCAT-GCU"},
+		{"Widget Pro", "Widget", 7, "Widget Pro", "Widget----"},
+		{"abc", "", -4, "abc", "---"},
+		{"", "", 0, "", ""},
+	}
+	scoring := AlignmentScoring{Match: 1, Mismatch: -1, GapOpen: 1, GapExtend: 1}
+	for _, c := range cases[:1] {
+		a := NeedlemanWunsch(c.s1, c.s2, scoring)
+		if a.Score != c.score {
+			t.Errorf("NeedlemanWunsch(%q, %q): Expected %v, got %v.", c.s1, c.s2, c.score, a.Score)
+		}
+	}
+	for _, c := range cases[1:] {
+		a := NeedlemanWunsch(c.s1, c.s2, DefaultAlignmentScoring)
+		if a.Score != c.score || a.Aligned1 != c.aligned1 || a.Aligned2 != c.aligned2 {
+			t.Errorf("NeedlemanWunsch(%q, %q): Expected %v\n%v\n%v\ngot %v\n%v",
+				c.s1, c.s2, c.score, c.aligned1, c.aligned2, a.Score, a)
+		}
+		if a.Start1 != 0 || a.End1 != len([]rune(c.s1)) || a.Start2 != 0 || a.End2 != len([]rune(c.s2)) {
+			t.Errorf("NeedlemanWunsch(%q, %q): Expected whole strings, got [%v,%v) [%v,%v).",
+				c.s1, c.s2, a.Start1, a.End1, a.Start2, a.End2)
+		}
+	}
+}
+
+func TestAlignmentIsConsistent(t *testing.T) {
+	scorings := []AlignmentScoring{
+		DefaultAlignmentScoring,
+		{Match: 1, Mismatch: -1, GapOpen: 1, GapExtend: 1},
+		{Match: 5, Mismatch: -4, GapOpen: 10, GapExtend: 1},
+	}
+	r := rand.New(rand.NewSource(1))
+	randomString := func() string {
+		chrs := make([]rune, r.Intn(12))
+		for i := range chrs {
+			chrs[i] = rune('a' + r.Intn(3))
+		}
+		return string(chrs)
+	}
+	for n := 0; n < 300; n++ {
+		s1, s2 := randomString(), randomString()
+		for _, scoring := range scorings {
+			for _, a := range []*Alignment{SmithWaterman(s1, s2, scoring), NeedlemanWunsch(s1, s2, scoring)} {
+				chrs1, chrs2 := []rune(s1), []rune(s2)
+				if strings.Replace(a.Aligned1, "-", "", -1) != string(chrs1[a.Start1:a.End1]) ||
+					strings.Replace(a.Aligned2, "-", "", -1) != string(chrs2[a.Start2:a.End2]) {
+					t.Errorf("Alignment of %q and %q does not match its spans:\n%v", s1, s2, a)
+				}
+				if score := alignedScore(a, scoring); score != a.Score {
+					t.Errorf("Alignment of %q and %q: Expected score %v, got %v.\n%v", s1, s2, score, a.Score, a)
+				}
+			}
+		}
+		// with these scores a global alignment is an optimal edit script
+		levenshtein := AlignmentScoring{Match: 0, Mismatch: -1, GapOpen: 1, GapExtend: 1}
+		if score, dist := NeedlemanWunsch(s1, s2, levenshtein).Score, EditDistance(s1, s2); score != -dist {
+			t.Errorf("NeedlemanWunsch(%q, %q): Expected %v, got %v.", s1, s2, -dist, score)
+		}
+	}
+}
+
+// alignedScore scores the aligned strings of an alignment.
+func alignedScore(a *Alignment, scoring AlignmentScoring) int {
+	chrs1, chrs2 := []rune(a.Aligned1), []rune(a.Aligned2)
+	score := 0
+	for i := range chrs1 {
+		switch {
+		case chrs1[i] == AlignmentGap:
+			if i > 0 && chrs1[i-1] == AlignmentGap {
+				score -= scoring.GapExtend
+			} else {
+				score -= scoring.GapOpen
+			}
+		case chrs2[i] == AlignmentGap:
+			if i > 0 && chrs2[i-1] == AlignmentGap {
+				score -= scoring.GapExtend
+			} else {
+				score -= scoring.GapOpen
+			}
+		case chrs1[i] == chrs2[i]:
+			score += scoring.Match
+		default:
+			score += scoring.Mismatch
+		}
+	}
+	return score
+}
+
+func TestSmithWatermanRatio(t *testing.T) {
+	assertRatioIs100(t, "SmithWatermanRatio", "Widget Pro", "2x Widget Pro 12.50", SmithWatermanRatio("Widget Pro", "2x Widget Pro 12.50"))
+	assertRatio(t, "SmithWatermanRatio", "Widget Pro", "2x Widgt Pro 12.50", 80, SmithWatermanRatio("Widget Pro", "2x Widgt Pro 12.50"))
+	assertRatio(t, "SmithWatermanRatio", "abc", "xyz", 0, SmithWatermanRatio("abc", "xyz"))
+	assertRatio(t, "SmithWatermanRatio", "", "", 0, SmithWatermanRatio("", ""))
+}
+
+func TestNeedlemanWunschRatio(t *testing.T) {
+	assertRatioIs100(t, "NeedlemanWunschRatio", "Widget Pro", "Widget Pro", NeedlemanWunschRatio("Widget Pro", "Widget Pro"))
+	assertRatio(t, "NeedlemanWunschRatio", "Widget Pro", "Widget", 35, NeedlemanWunschRatio("Widget Pro", "Widget"))
+	assertRatio(t, "NeedlemanWunschRatio", "abc", "xyz", 0, NeedlemanWunschRatio("abc", "xyz"))
+	assertRatio(t, "NeedlemanWunschRatio", "", "", 0, NeedlemanWunschRatio("", ""))
+}
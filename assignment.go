@@ -0,0 +1,184 @@
+package fuzzy
+
+import "math"
+
+// TokenPair is a token of the first string paired with a token of the
+// second string, and the score of the pair. Tokens left without a
+// partner are paired with an empty string.
+type TokenPair struct {
+	Token1 string
+	Token2 string
+	Score  int
+}
+
+// Assignment is the result of AssignTokens.
+type Assignment struct {
+	Score int
+	Pairs []TokenPair
+}
+
+// AssignmentRatio computes a score of how close two strings are by pairing
+// their tokens one to one so that the total Ratio of the pairs is as high
+// as possible, and averaging the scores of the pairs. Unlike
+// MongeElkanRatio, a token is never paired twice, so "john john" does not
+// match "john smith" perfectly. Tokens left over when the strings have
+// different numbers of tokens score 0.
+func AssignmentRatio(s1, s2 string) int {
+	return AssignTokens(s1, s2, Ratio, 100, nil).Score
+}
+
+// AssignmentScorer returns a scorer similar to AssignmentRatio using the
+// given inner scorer, penalty and options (see AssignTokens).
+func AssignmentScorer(inner func(string, string) int, penalty int, opts *TokenOptions) func(string, string) int {
+	return func(s1, s2 string) int {
+		return AssignTokens(s1, s2, inner, penalty, opts).Score
+	}
+}
+
+// AssignTokens finds the one-to-one pairing of the tokens of s1 and s2
+// with the highest total score under the inner scorer, using the
+// Hungarian algorithm, and returns the pairs along with their average
+// score. When the strings have different numbers of tokens, each
+// token left over scores 100-penalty, so a penalty of 100 counts it
+// as a complete mismatch and a penalty of 0 ignores missing tokens.
+// Pairs are listed in the order of the tokens of s1, followed by the
+// tokens of s2 left over.
+// Tokens are cleansed whatever opts says; its other options, such as a
+// Tokenizer, Synonyms and Stopwords, apply as for TokenSetRatio, and nil
+// uses the defaults.
+func AssignTokens(s1, s2 string, inner func(string, string) int, penalty int, opts *TokenOptions) *Assignment {
+	o := opts.cleansed()
+	tokens1, tokens2 := o.tokenize(s1), o.tokenize(s2)
+	if len(tokens1) == 0 || len(tokens2) == 0 {
+		return &Assignment{Score: 0, Pairs: []TokenPair{}}
+	}
+
+	scores := make([][]int, len(tokens1))
+	for i, token1 := range tokens1 {
+		scores[i] = make([]int, len(tokens2))
+		for j, token2 := range tokens2 {
+			scores[i][j] = inner(token1, token2)
+		}
+	}
+	assigned := maxAssignment(scores)
+
+	pairs := make([]TokenPair, 0, len(tokens1)+len(tokens2))
+	paired2 := make([]bool, len(tokens2))
+	total := 0
+	for i, j := range assigned {
+		if j < 0 {
+			pairs = append(pairs, TokenPair{Token1: tokens1[i], Score: 100 - penalty})
+		} else {
+			pairs = append(pairs, TokenPair{Token1: tokens1[i], Token2: tokens2[j], Score: scores[i][j]})
+			paired2[j] = true
+		}
+		total += pairs[len(pairs)-1].Score
+	}
+	for j, paired := range paired2 {
+		if !paired {
+			pairs = append(pairs, TokenPair{Token2: tokens2[j], Score: 100 - penalty})
+			total += 100 - penalty
+		}
+	}
+	return &Assignment{Score: int(round(float64(total) / float64(len(pairs)))), Pairs: pairs}
+}
+
+// maxAssignment returns, for each row of the score matrix, the column
+// assigned to it in an assignment maximizing the total score, or -1 for
+// rows left unassigned because there are more rows than columns.
+func maxAssignment(scores [][]int) []int {
+	n := len(scores)
+	if n == 0 {
+		return []int{}
+	}
+	m := len(scores[0])
+
+	// the Hungarian algorithm below needs no more rows than columns
+	transposed := n > m
+	if transposed {
+		t := make([][]int, m)
+		for j := range t {
+			t[j] = make([]int, n)
+			for i := range scores {
+				t[j][i] = scores[i][j]
+			}
+		}
+		scores, n, m = t, m, n
+	}
+
+	// minimize costs with row and column potentials u and v; p[j] is
+	// the row assigned to column j, and rows and columns are numbered
+	// from 1 so that column 0 can stand for the row being added
+	maxScore := 0
+	for _, row := range scores {
+		for _, score := range row {
+			if score > maxScore {
+				maxScore = score
+			}
+		}
+	}
+	cost := func(i, j int) int {
+		return maxScore - scores[i-1][j-1]
+	}
+	u, v := make([]int, n+1), make([]int, m+1)
+	p, way := make([]int, m+1), make([]int, m+1)
+	for i := 1; i <= n; i++ {
+		p[0] = i
+		j0 := 0
+		minv := make([]int, m+1)
+		for j := range minv {
+			minv[j] = math.MaxInt32
+		}
+		used := make([]bool, m+1)
+		for p[j0] != 0 {
+			used[j0] = true
+			i0, delta, j1 := p[j0], math.MaxInt32, 0
+			for j := 1; j <= m; j++ {
+				if used[j] {
+					continue
+				}
+				if cur := cost(i0, j) - u[i0] - v[j]; cur < minv[j] {
+					minv[j], way[j] = cur, j0
+				}
+				if minv[j] < delta {
+					delta, j1 = minv[j], j
+				}
+			}
+			for j := 0; j <= m; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+		}
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+
+	if transposed {
+		assigned := make([]int, m)
+		for j := range assigned {
+			assigned[j] = -1
+		}
+		for j := 1; j <= m; j++ {
+			if p[j] != 0 {
+				assigned[j-1] = p[j] - 1
+			}
+		}
+		// columns of the transposed matrix are the original rows
+		return assigned
+	}
+	assigned := make([]int, n)
+	for j := 1; j <= m; j++ {
+		if p[j] != 0 {
+			assigned[p[j]-1] = j - 1
+		}
+	}
+	return assigned
+}
@@ -0,0 +1,116 @@
+package fuzzy
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestAssignTokens(t *testing.T) {
+	a := AssignTokens("richmond jonathon", "Jonathan Richman", Ratio, 100, nil)
+	expectedPairs := []TokenPair{
+		{"richmond", "richman", 80},
+		{"jonathon", "jonathan", 88},
+	}
+	if !reflect.DeepEqual(a.Pairs, expectedPairs) {
+		t.Errorf("Expected pairs %v, got %v", expectedPairs, a.Pairs)
+	}
+	assertRatio(t, "AssignTokens", "richmond jonathon", "Jonathan Richman", 84, a.Score)
+
+	a = AssignTokens("John", "John Smith", Ratio, 100, nil)
+	expectedPairs = []TokenPair{
+		{"john", "john", 100},
+		{"", "smith", 0},
+	}
+	if !reflect.DeepEqual(a.Pairs, expectedPairs) {
+		t.Errorf("Expected pairs %v, got %v", expectedPairs, a.Pairs)
+	}
+	assertRatio(t, "AssignTokens", "John", "John Smith", 50, a.Score)
+	assertRatioIs100(t, "AssignTokens", "John", "John Smith", AssignTokens("John", "John Smith", Ratio, 0, nil).Score)
+	assertRatio(t, "AssignTokens", "John Smith", "John", 75, AssignTokens("John Smith", "John", Ratio, 50, nil).Score)
+
+	a = AssignTokens("", "John", Ratio, 100, nil)
+	if a.Score != 0 || len(a.Pairs) != 0 {
+		t.Errorf("Expected an empty assignment, got %v", a)
+	}
+}
+
+func TestAssignmentRatio(t *testing.T) {
+	// Monge-Elkan pairs both tokens with the same "john"
+	s1, s2 := "john john", "john smith"
+	assertRatioIs100(t, "MongeElkanRatio", s1, s2, MongeElkanRatio(s1, s2))
+	assertRatioIsNot100(t, "AssignmentRatio", s1, s2, AssignmentRatio(s1, s2))
+
+	assertRatioIs100(t, "AssignmentRatio", "Smith, John", "john smith", AssignmentRatio("Smith, John", "john smith"))
+
+	choices := []string{"Jonathan Richman", "Jonathan Jonathan", "Richman"}
+	match, err := ExtractOne("richmond jonathon", choices, AssignmentScorer(JaroWinklerRatio, 100, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertMatch(t, "richmond jonathon", "Jonathan Richman", match.Match)
+}
+
+func TestMaxAssignment(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 200; trial++ {
+		n, m := 1+rng.Intn(5), 1+rng.Intn(5)
+		scores := make([][]int, n)
+		for i := range scores {
+			scores[i] = make([]int, m)
+			for j := range scores[i] {
+				scores[i][j] = rng.Intn(101)
+			}
+		}
+
+		assigned := maxAssignment(scores)
+		total, seen := 0, make(map[int]bool)
+		for i, j := range assigned {
+			if j < 0 {
+				continue
+			}
+			if seen[j] {
+				t.Fatalf("column %v assigned twice in %v", j, assigned)
+			}
+			seen[j] = true
+			total += scores[i][j]
+		}
+		if len(seen) != min(n, m) {
+			t.Fatalf("expected %v assigned rows, got %v", min(n, m), assigned)
+		}
+		if best := bruteForceAssignment(scores, 0, make([]bool, m)); total != best {
+			t.Fatalf("expected total %v for %v, got %v", best, scores, total)
+		}
+	}
+}
+
+func bruteForceAssignment(scores [][]int, row int, used []bool) int {
+	if row == len(scores) {
+		return 0
+	}
+	// the row may stay unassigned if there are more rows than columns
+	best := -1
+	if len(scores)-row > countUnused(used) {
+		best = bruteForceAssignment(scores, row+1, used)
+	}
+	for j := range used {
+		if !used[j] {
+			used[j] = true
+			if total := scores[row][j] + bruteForceAssignment(scores, row+1, used); total > best {
+				best = total
+			}
+			used[j] = false
+		}
+	}
+	return best
+}
+
+func countUnused(used []bool) int {
+	n := 0
+	for _, u := range used {
+		if !u {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,137 @@
+package fuzzy
+
+import "strings"
+
+// DiffKind is the kind of a DiffOp.
+type DiffKind int
+
+// The kinds of DiffOp.
+const (
+	DiffEqual DiffKind = iota
+	DiffReplace
+	DiffInsert
+	DiffDelete
+)
+
+// DiffOp is a part of a Diff: Old is replaced with New, and either is
+// empty for insertions and deletions. For equal parts Old and New are
+// the same.
+type DiffOp struct {
+	Kind     DiffKind
+	Old, New string
+}
+
+// Diff is the list of operations turning a string into another, in the
+// order they apply, computed from the same edit operations as Ratio.
+type Diff struct {
+	Ops []DiffOp
+	sep string
+}
+
+// CharDiff computes the differences between two strings rune by rune.
+func CharDiff(s1, s2 string) *Diff {
+	return diffUnits(strings.Split(s1, ""), strings.Split(s2, ""), "")
+}
+
+// TokenDiff computes the differences between two strings token by token,
+// splitting them on whitespace, so that a word differing by a single
+// character is shown as replaced as a whole. The parts of the Diff are
+// joined by single spaces.
+func TokenDiff(s1, s2 string) *Diff {
+	return diffUnits(strings.Fields(s1), strings.Fields(s2), " ")
+}
+
+// diffUnits diffs two lists of runes or tokens.
+func diffUnits(units1, units2 []string, sep string) *Diff {
+	opcodes := OpcodesSlice(units1, units2)
+	d := &Diff{Ops: make([]DiffOp, len(opcodes)), sep: sep}
+	for i, oc := range opcodes {
+		d.Ops[i] = DiffOp{
+			Kind: oc.Kind,
+			Old:  strings.Join(units1[oc.SrcStart:oc.SrcEnd], sep),
+			New:  strings.Join(units2[oc.DestStart:oc.DestEnd], sep),
+		}
+	}
+	return d
+}
+
+// Equal reports whether the strings compared are identical.
+func (d *Diff) Equal() bool {
+	for _, op := range d.Ops {
+		if op.Kind != DiffEqual {
+			return false
+		}
+	}
+	return true
+}
+
+// Inline formats the diff on a single line, with deleted text enclosed
+// in [- and -] and inserted text in {+ and +}, as git diff --word-diff
+// does:
+//
+//	CharDiff("Philladelphia", "Philadelphia").Inline() == "Phil[-l-]adelphia"
+func (d *Diff) Inline() string {
+	parts := make([]string, len(d.Ops))
+	for i, op := range d.Ops {
+		switch op.Kind {
+		case DiffEqual:
+			parts[i] = op.Old
+		case DiffReplace:
+			parts[i] = "[-" + op.Old + "-]{+" + op.New + "+}"
+		case DiffInsert:
+			parts[i] = "{+" + op.New + "+}"
+		case DiffDelete:
+			parts[i] = "[-" + op.Old + "-]"
+		}
+	}
+	return strings.Join(parts, d.sep)
+}
+
+// Unified formats the diff as the old string on a line starting with
+// "- " and the new string on a line starting with "+ ", each followed by
+// a guide line starting with "? " that marks replaced runes with ^,
+// deleted runes with - and inserted runes with +, like Python's
+// difflib.ndiff. Guide lines assume every rune is one column wide.
+// If the strings are identical, the result is a single line starting
+// with two spaces.
+func (d *Diff) Unified() string {
+	var oldLine, newLine, oldGuide, newGuide []string
+	for _, op := range d.Ops {
+		oldMark, newMark := " ", " "
+		switch op.Kind {
+		case DiffReplace:
+			oldMark, newMark = "^", "^"
+		case DiffInsert:
+			newMark = "+"
+		case DiffDelete:
+			oldMark = "-"
+		}
+		if op.Kind != DiffInsert {
+			oldLine = append(oldLine, op.Old)
+			oldGuide = append(oldGuide, strings.Repeat(oldMark, len([]rune(op.Old))))
+		}
+		if op.Kind != DiffDelete {
+			newLine = append(newLine, op.New)
+			newGuide = append(newGuide, strings.Repeat(newMark, len([]rune(op.New))))
+		}
+	}
+	if d.Equal() {
+		return "  " + strings.Join(oldLine, d.sep) + "\n"
+	}
+
+	var b strings.Builder
+	writeLines := func(prefix string, line, guide []string) {
+		b.WriteString(prefix + strings.Join(line, d.sep) + "\n")
+		if g := strings.TrimRight(strings.Join(guide, d.sep), " "); g != "" {
+			b.WriteString("? " + g + "\n")
+		}
+	}
+	writeLines("- ", oldLine, oldGuide)
+	writeLines("+ ", newLine, newGuide)
+	return b.String()
+}
+
+// String formats the diff inline.
+func (d *Diff) String() string {
+	return d.Inline()
+}
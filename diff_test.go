@@ -0,0 +1,70 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCharDiff(t *testing.T) {
+	d := CharDiff("Philladelphia Phillies", "Philadelphia Phillies")
+	expectedOps := []DiffOp{
+		{DiffEqual, "Phil", "Phil"},
+		{DiffDelete, "l", ""},
+		{DiffEqual, "adelphia Phillies", "adelphia Phillies"},
+	}
+	if !reflect.DeepEqual(d.Ops, expectedOps) {
+		t.Errorf("Expected ops %v, got %v", expectedOps, d.Ops)
+	}
+
+	cases := []struct {
+		s1, s2          string
+		inline, unified string
+	}{
+		{"Philladelphia Phillies", "Philadelphia Phillies", "Phil[-l-]adelphia Phillies",
+			"- Philladelphia Phillies\n?     -\n+ Philadelphia Phillies\n"},
+		{"kitten", "sitting", "[-k-]{+s+}itt[-e-]{+i+}n{+g+}",
+			"- kitten\n? ^   ^\n+ sitting\n? ^   ^ +\n"},
+		{"the new york mets", "new york mets!", "[-the -]new york mets{+!+}",
+			"- the new york mets\n? ----\n+ new york mets!\n?              +\n"},
+		{"Zürich", "Zurich", "Z[-ü-]{+u+}rich", "- Zürich\n?  ^\n+ Zurich\n?  ^\n"},
+		{"abc", "abc", "abc", "  abc\n"},
+		{"", "ab", "{+ab+}", "- \n+ ab\n? ++\n"},
+		{"", "", "", "  \n"},
+	}
+	for _, c := range cases {
+		d := CharDiff(c.s1, c.s2)
+		if actual := d.Inline(); actual != c.inline {
+			t.Errorf("CharDiff(%q, %q).Inline(): Expected %q, got %q.", c.s1, c.s2, c.inline, actual)
+		}
+		if actual := d.Unified(); actual != c.unified {
+			t.Errorf("CharDiff(%q, %q).Unified(): Expected %q, got %q.", c.s1, c.s2, c.unified, actual)
+		}
+		if d.Equal() != (c.s1 == c.s2) {
+			t.Errorf("CharDiff(%q, %q).Equal(): Expected %v.", c.s1, c.s2, c.s1 == c.s2)
+		}
+	}
+}
+
+func TestTokenDiff(t *testing.T) {
+	cases := []struct {
+		s1, s2          string
+		inline, unified string
+	}{
+		{"Philladelphia Phillies", "Philadelphia Phillies", "[-Philladelphia-]{+Philadelphia+} Phillies",
+			"- Philladelphia Phillies\n? ^^^^^^^^^^^^^\n+ Philadelphia Phillies\n? ^^^^^^^^^^^^\n"},
+		{"the new york mets", "new  york mets", "[-the-] new york mets",
+			"- the new york mets\n? ---\n+ new york mets\n"},
+		{"new york", "new york yankees", "new york {+yankees+}",
+			"- new york\n+ new york yankees\n?          +++++++\n"},
+		{"new york", " new york ", "new york", "  new york\n"},
+	}
+	for _, c := range cases {
+		d := TokenDiff(c.s1, c.s2)
+		if actual := d.Inline(); actual != c.inline {
+			t.Errorf("TokenDiff(%q, %q).Inline(): Expected %q, got %q.", c.s1, c.s2, c.inline, actual)
+		}
+		if actual := d.Unified(); actual != c.unified {
+			t.Errorf("TokenDiff(%q, %q).Unified(): Expected %q, got %q.", c.s1, c.s2, c.unified, actual)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package fuzzy
+
+import "math/bits"
+
+// ExactPartialRatio computes a score of how close a string is with the
+// most similar substring from another string, like PartialRatio, except
+// every substring that can score best is compared rather than only those
+// aligned with matching blocks, so the true maximum is found. The
+// substrings are those of the length of the shorter string, and the
+// shorter prefixes and suffixes of the longer string, as in newer
+// versions of fuzzywuzzy and in rapidfuzz. Strings of equal length are
+// compared both ways, so the order of arguments never matters.
+// Returns an integer score [0,100], higher score indicates
+// that the string and substring are closer.
+func ExactPartialRatio(s1, s2 string) int {
+	return ExactPartialRatioAlignment(s1, s2).Score
+}
+
+// ExactPartialRatioAlignment computes the same score as ExactPartialRatio
+// along with the position of the best matching substring, as
+// PartialRatioAlignment does for PartialRatio.
+func ExactPartialRatioAlignment(s1, s2 string) *ScoreAlignment {
+	chrs1, chrs2 := []rune(s1), []rune(s2)
+	a := &ScoreAlignment{SrcEnd: len(chrs1), DestEnd: len(chrs2)}
+	if len(chrs1) > len(chrs2) {
+		a.Score, a.SrcStart, a.SrcEnd = exactPartialRatioWindow(chrs2, chrs1)
+	} else {
+		a.Score, a.DestStart, a.DestEnd = exactPartialRatioWindow(chrs1, chrs2)
+		if len(chrs1) == len(chrs2) && a.Score < 100 {
+			if score, start, end := exactPartialRatioWindow(chrs2, chrs1); score > a.Score {
+				a.Score, a.SrcStart, a.SrcEnd = score, start, end
+				a.DestStart, a.DestEnd = 0, len(chrs2)
+			}
+		}
+	}
+	a.SrcByteStart, a.SrcByteEnd = byteOffset(s1, a.SrcStart), byteOffset(s1, a.SrcEnd)
+	a.DestByteStart, a.DestByteEnd = byteOffset(s2, a.DestStart), byteOffset(s2, a.DestEnd)
+	return a
+}
+
+// exactPartialRatioWindow returns the score of the substring of longer
+// most similar to shorter, and where that substring starts and ends.
+// A substring ending, or for suffixes starting, with a rune absent from
+// shorter is skipped, as dropping that rune gives a substring at least
+// as similar.
+func exactPartialRatioWindow(shorter, longer []rune) (int, int, int) {
+	m, n := len(shorter), len(longer)
+	if m == 0 {
+		return 0, 0, 0
+	}
+	pm := newLCSPattern(shorter)
+
+	bestScore, bestStart, bestEnd := -1.0, 0, 0
+	try := func(start, end int) bool {
+		window := longer[start:end]
+		lenSum := m + len(window)
+		r := float64(2*pm.lcs(window)) / float64(lenSum)
+		if r > bestScore {
+			bestScore, bestStart, bestEnd = r, start, end
+		}
+		return bestScore == 1
+	}
+	for end := 1; end < m; end++ {
+		if pm.contains(longer[end-1]) && try(0, end) {
+			return 100, bestStart, bestEnd
+		}
+	}
+	for start := 0; start+m <= n; start++ {
+		if pm.contains(longer[start+m-1]) && try(start, start+m) {
+			return 100, bestStart, bestEnd
+		}
+	}
+	for start := n - m + 1; start < n; start++ {
+		if pm.contains(longer[start]) && try(start, n) {
+			return 100, bestStart, bestEnd
+		}
+	}
+	if bestScore < 0 {
+		// no rune in common
+		return 0, 0, m
+	}
+	return int(round(100 * bestScore)), bestStart, bestEnd
+}
+
+// lcsPattern holds, for each rune of a pattern, the bit vector of its
+// positions, for computing the length of the longest common subsequence
+// of the pattern and other strings with the bit-parallel algorithm of
+// Hyyrö, 64 runes at a time.
+type lcsPattern struct {
+	words int
+	masks map[rune][]uint64
+}
+
+func newLCSPattern(chrs []rune) *lcsPattern {
+	p := &lcsPattern{words: (len(chrs) + 63) / 64, masks: make(map[rune][]uint64)}
+	for i, chr := range chrs {
+		mask, ok := p.masks[chr]
+		if !ok {
+			mask = make([]uint64, p.words)
+			p.masks[chr] = mask
+		}
+		mask[i/64] |= 1 << uint(i%64)
+	}
+	return p
+}
+
+func (p *lcsPattern) contains(chr rune) bool {
+	_, ok := p.masks[chr]
+	return ok
+}
+
+// lcs returns the length of the longest common subsequence of the
+// pattern and chrs. The zero bits of s mark the positions of the
+// pattern in a longest common subsequence of the text read so far.
+func (p *lcsPattern) lcs(chrs []rune) int {
+	s := make([]uint64, p.words)
+	for i := range s {
+		s[i] = ^uint64(0)
+	}
+	for _, chr := range chrs {
+		mask, ok := p.masks[chr]
+		if !ok {
+			continue
+		}
+		var carry uint64
+		for i, x := range s {
+			u := x & mask[i]
+			var sum uint64
+			sum, carry = bits.Add64(x, u, carry)
+			s[i] = sum | (x - u)
+		}
+	}
+	lcs := 0
+	for _, x := range s {
+		lcs += bits.OnesCount64(^x)
+	}
+	return lcs
+}
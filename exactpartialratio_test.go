@@ -0,0 +1,95 @@
+package fuzzy
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestExactPartialRatio(t *testing.T) {
+	// PartialRatio scores are kept alongside to show where the modes differ
+	cases := []struct {
+		s1, s2       string
+		partial      int
+		exactPartial int
+	}{
+		{"new york mets", "the new york mets vs atlanta braves", 100, 100},
+		{"this is a test", "this is a test!", 100, 100},
+		// PartialRatio depends on the order of strings of equal length
+		{"york at", "at york", 73, 73},
+		{"at york", "york at", 57, 73},
+		{"yankees the", "new yankees", 78, 78},
+		{"new yankees", "yankees the", 64, 78},
+		// windows not aligned with a matching block, or prefixes and
+		// suffixes shorter than the shorter string
+		{"abcd", "xbcdyabc", 75, 86},
+		{"fuzzy wuzzy was a bear", "wuzzy fuzzy was a bear", 91, 93},
+		{"ab", "ba", 50, 67},
+		{"ax", "bxa", 50, 67},
+		{"abc", "xyz", 0, 0},
+		{"", "abc", 0, 0},
+		{"", "", 0, 0},
+	}
+	for _, c := range cases {
+		assertRatio(t, "PartialRatio", c.s1, c.s2, c.partial, PartialRatio(c.s1, c.s2))
+		assertRatio(t, "ExactPartialRatio", c.s1, c.s2, c.exactPartial, ExactPartialRatio(c.s1, c.s2))
+		assertRatio(t, "ExactPartialRatio", c.s2, c.s1, c.exactPartial, ExactPartialRatio(c.s2, c.s1))
+	}
+}
+
+func TestExactPartialRatioAlignment(t *testing.T) {
+	a := ExactPartialRatioAlignment("abcd", "xbcdyabc")
+	expected := ScoreAlignment{86, 0, 4, 5, 8, 0, 4, 5, 8}
+	if *a != expected {
+		t.Errorf("ExactPartialRatioAlignment: Expected %+v, got %+v.", expected, *a)
+	}
+
+	a = ExactPartialRatioAlignment("Ünïcode", "ünïcode: a UTF-8 string")
+	expected = ScoreAlignment{86, 0, 7, 0, 7, 0, 9, 0, 9}
+	if *a != expected {
+		t.Errorf("ExactPartialRatioAlignment: Expected %+v, got %+v.", expected, *a)
+	}
+}
+
+func TestExactPartialRatioIsExact(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	randomString := func(n int) string {
+		chrs := make([]rune, 1+r.Intn(n))
+		for i := range chrs {
+			chrs[i] = rune('a' + r.Intn(4))
+		}
+		return string(chrs)
+	}
+	for n := 0; n < 2000; n++ {
+		s1, s2 := randomString(8), randomString(15)
+		if len(s1) == len(s2) {
+			continue
+		}
+		expected := bruteForcePartialRatio([]rune(s1), []rune(s2))
+		assertRatio(t, "ExactPartialRatio", s1, s2, expected, ExactPartialRatio(s1, s2))
+	}
+
+	// patterns longer than a machine word
+	s1, s2 := randomString(150)+strings.Repeat("d", 70), randomString(300)
+	assertRatio(t, "ExactPartialRatio", s1, s2, bruteForcePartialRatio([]rune(s1), []rune(s2)), ExactPartialRatio(s1, s2))
+}
+
+// bruteForcePartialRatio compares the shorter string with every substring
+// of the longer one of its length, and with every shorter prefix and suffix.
+func bruteForcePartialRatio(chrs1, chrs2 []rune) int {
+	if len(chrs1) > len(chrs2) {
+		chrs1, chrs2 = chrs2, chrs1
+	}
+	best := 0.0
+	for i := 0; i <= len(chrs2); i++ {
+		for j := i; j <= len(chrs2) && j-i <= len(chrs1); j++ {
+			if j-i < len(chrs1) && i > 0 && j < len(chrs2) {
+				continue
+			}
+			if r := floatRatio(chrs1, chrs2[i:j]); r > best {
+				best = r
+			}
+		}
+	}
+	return int(round(100 * best))
+}
@@ -0,0 +1,139 @@
+package fuzzy
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Occurrence is an approximate occurrence of a pattern in a text: the
+// substring [Start,End) of the text, in runes, or [ByteStart,ByteEnd)
+// in bytes, is Distance edits away from the pattern.
+type Occurrence struct {
+	Start, End         int
+	ByteStart, ByteEnd int
+	Distance           int
+}
+
+// FindAll finds every occurrence of pattern in text within maxDist edits
+// (insertions, deletions or substitutions of a rune), using Sellers'
+// algorithm. Unlike PartialRatio, which only scores the best matching
+// substring, it locates all of them, so every mention of a name in a
+// page is found. Occurrences do not overlap and are chosen from left to
+// right: of overlapping candidates the one with the fewest edits is
+// kept, then the one closest in length to the pattern, then the
+// earliest. maxDist is capped below the length of the pattern, so that
+// occurrences are never empty.
+//
+// Runes are compared as is; lowercase or otherwise process both
+// strings beforehand for a case-insensitive search.
+func FindAll(pattern, text string, maxDist int) []Occurrence {
+	occurrences, _ := findAll([]rune(pattern), strings.NewReader(text), maxDist)
+	return occurrences
+}
+
+// FindAllReader finds occurrences of pattern in the text read from r,
+// like FindAll, reading it a rune at a time so that the text is never
+// held in memory. The occurrences found before an error occurred are
+// returned with the error; io.EOF is not an error.
+func FindAllReader(pattern string, r io.Reader, maxDist int) ([]Occurrence, error) {
+	rr, ok := r.(io.RuneReader)
+	if !ok {
+		rr = bufio.NewReader(r)
+	}
+	return findAll([]rune(pattern), rr, maxDist)
+}
+
+// findCell is the best alignment of a prefix of the pattern with a
+// substring of the text ending at the current position.
+type findCell struct {
+	dist             int
+	start, byteStart int
+}
+
+func findAll(pattern []rune, r io.RuneReader, maxDist int) ([]Occurrence, error) {
+	m := len(pattern)
+	if m == 0 || maxDist < 0 {
+		return []Occurrence{}, nil
+	}
+	if maxDist >= m {
+		maxDist = m - 1
+	}
+
+	// col[i] aligns pattern[:i] with the text read so far; any
+	// substring may be the start of an occurrence, so row 0 is free
+	col := make([]findCell, m+1)
+	for i := range col {
+		col[i].dist = i
+	}
+	occurrences := []Occurrence{}
+	var pending *Occurrence
+	pos, byteOffset, lastEnd := 0, 0, 0
+	for {
+		chr, size, err := r.ReadRune()
+		if err != nil {
+			if pending != nil {
+				occurrences = append(occurrences, *pending)
+			}
+			if err == io.EOF {
+				err = nil
+			}
+			return occurrences, err
+		}
+		pos++
+		byteOffset += size
+
+		diag := col[0]
+		col[0] = findCell{start: pos, byteStart: byteOffset}
+		for i := 1; i <= m; i++ {
+			cell := diag
+			if pattern[i-1] != chr {
+				cell.dist++
+			}
+			// the rune read is extra, or pattern[i-1] is missing
+			if col[i].dist+1 < cell.dist {
+				cell = col[i]
+				cell.dist++
+			}
+			if col[i-1].dist+1 < cell.dist {
+				cell = col[i-1]
+				cell.dist++
+			}
+			diag, col[i] = col[i], cell
+		}
+
+		best := col[m]
+		if best.dist > maxDist || best.start < lastEnd {
+			continue
+		}
+		candidate := &Occurrence{
+			Start:     best.start,
+			End:       pos,
+			ByteStart: best.byteStart,
+			ByteEnd:   byteOffset,
+			Distance:  best.dist,
+		}
+		switch {
+		case pending == nil:
+			pending = candidate
+		case candidate.Start < pending.End:
+			if candidate.Distance < pending.Distance ||
+				(candidate.Distance == pending.Distance && lengthDiff(candidate, m) < lengthDiff(pending, m)) {
+				pending = candidate
+			}
+		default:
+			occurrences = append(occurrences, *pending)
+			lastEnd = pending.End
+			pending = candidate
+		}
+	}
+}
+
+// lengthDiff returns how far the length of o is from m runes.
+func lengthDiff(o *Occurrence, m int) int {
+	d := o.End - o.Start - m
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,96 @@
+package fuzzy
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindAll(t *testing.T) {
+	text := "Live at the Fillmore tonight, then the Filmore West and the Fillmore East."
+	expected := []Occurrence{
+		{12, 20, 12, 20, 0},
+		{39, 46, 39, 46, 1},
+		{60, 68, 60, 68, 0},
+	}
+	if actual := FindAll("Fillmore", text, 1); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("FindAll: Expected %v, got %v.", expected, actual)
+	}
+	expected = []Occurrence{{12, 20, 12, 20, 0}, {60, 68, 60, 68, 0}}
+	if actual := FindAll("Fillmore", text, 0); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("FindAll: Expected %v, got %v.", expected, actual)
+	}
+
+	// rune and byte offsets differ after non-ASCII text
+	expected = []Occurrence{{11, 15, 11, 16, 1}}
+	if actual := FindAll("Cafe", "Meet me at Café Noir", 1); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("FindAll: Expected %v, got %v.", expected, actual)
+	}
+
+	for _, c := range []struct {
+		pattern, text string
+		maxDist       int
+	}{
+		{"", "text", 1},
+		{"abc", "", 1},
+		{"abc", "xyz", -1},
+		{"abc", "xyz", 1},
+	} {
+		if actual := FindAll(c.pattern, c.text, c.maxDist); len(actual) != 0 {
+			t.Errorf("FindAll(%q, %q, %v): Expected no occurrences, got %v.", c.pattern, c.text, c.maxDist, actual)
+		}
+	}
+}
+
+func TestFindAllOccurrencesAreValid(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	randomString := func(n int) string {
+		chrs := make([]rune, n)
+		for i := range chrs {
+			chrs[i] = rune('a' + r.Intn(3))
+		}
+		return string(chrs)
+	}
+	for n := 0; n < 300; n++ {
+		pattern, text, maxDist := randomString(1+r.Intn(6)), randomString(r.Intn(40)), r.Intn(3)
+		end := 0
+		for _, o := range FindAll(pattern, text, maxDist) {
+			dist := EditDistance(pattern, text[o.ByteStart:o.ByteEnd])
+			if o.Start < end || o.Start >= o.End || o.Distance != dist || dist > maxDist {
+				t.Errorf("FindAll(%q, %q, %v): Invalid occurrence %v.", pattern, text, maxDist, o)
+			}
+			end = o.End
+		}
+	}
+}
+
+func TestFindAllReader(t *testing.T) {
+	text := strings.Repeat("lorem ipsum dolor sit amet ", 1000) + "Madison Square Garden"
+	occurrences, err := FindAllReader("Madisson Square Garden", strings.NewReader(text), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(occurrences) != 1 || text[occurrences[0].ByteStart:occurrences[0].ByteEnd] != "Madison Square Garden" {
+		t.Errorf("FindAllReader: Expected to find Madison Square Garden, got %v.", occurrences)
+	}
+
+	failing := io.MultiReader(strings.NewReader("at the Fillmore, "), &errReader{errors.New("read failed")})
+	occurrences, err = FindAllReader("Fillmore", failing, 1)
+	if err == nil || err.Error() != "read failed" {
+		t.Errorf("FindAllReader: Expected read error, got %v.", err)
+	}
+	if len(occurrences) != 1 || occurrences[0].Start != 7 {
+		t.Errorf("FindAllReader: Expected the occurrence read before the error, got %v.", occurrences)
+	}
+}
+
+type errReader struct {
+	err error
+}
+
+func (r *errReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
@@ -21,13 +21,54 @@ func Ratio(s1, s2 string) int {
 // Returns an integer score [0,100], higher score indicates
 // that the string and substring are closer.
 func PartialRatio(s1, s2 string) int {
-	shorter, longer := []rune(s1), []rune(s2)
+	return partialRatio([]rune(s1), []rune(s2))
+}
+
+// ScoreAlignment is the result of PartialRatioAlignment: a score and
+// the spans of the two strings that were compared to obtain it.
+// The span [SrcStart,SrcEnd) of the first string was compared with
+// the span [DestStart,DestEnd) of the second, in runes; the Byte
+// fields hold the same spans in bytes, for slicing the strings.
+type ScoreAlignment struct {
+	Score                      int
+	SrcStart, SrcEnd           int
+	DestStart, DestEnd         int
+	SrcByteStart, SrcByteEnd   int
+	DestByteStart, DestByteEnd int
+}
+
+// PartialRatioAlignment computes the same score as PartialRatio along
+// with the position of the best matching substring: one of the spans is
+// the whole shorter string, and the other the substring of the longer
+// string it was compared with. If both strings are empty, all the spans
+// are empty.
+func PartialRatioAlignment(s1, s2 string) *ScoreAlignment {
+	chrs1, chrs2 := []rune(s1), []rune(s2)
+	a := &ScoreAlignment{SrcEnd: len(chrs1), DestEnd: len(chrs2)}
+	if len(chrs1) > len(chrs2) {
+		a.Score, a.SrcStart, a.SrcEnd = partialRatioWindow(chrs2, chrs1)
+	} else {
+		a.Score, a.DestStart, a.DestEnd = partialRatioWindow(chrs1, chrs2)
+	}
+	a.SrcByteStart, a.SrcByteEnd = byteOffset(s1, a.SrcStart), byteOffset(s1, a.SrcEnd)
+	a.DestByteStart, a.DestByteEnd = byteOffset(s2, a.DestStart), byteOffset(s2, a.DestEnd)
+	return a
+}
+
+func partialRatio(shorter, longer []rune) int {
 	if len(shorter) > len(longer) {
 		longer, shorter = shorter, longer
 	}
+	score, _, _ := partialRatioWindow(shorter, longer)
+	return score
+}
+
+// partialRatioWindow returns the score of the substring of longer
+// most similar to shorter, and where that substring starts and ends.
+func partialRatioWindow(shorter, longer []rune) (int, int, int) {
 	matchingBlocks := getMatchingBlocks(shorter, longer)
 
-	bestScore := 0.0
+	bestScore, bestStart, bestEnd := -1.0, 0, 0
 	for _, block := range matchingBlocks {
 		longStart := block.dpos - block.spos
 		if longStart < 0 {
@@ -41,16 +82,30 @@ func PartialRatio(s1, s2 string) int {
 
 		r := floatRatio(shorter, longSubStr)
 		if r > .995 {
-			return 100
+			return 100, longStart, longEnd
 		} else if r > bestScore {
-			bestScore = r
+			bestScore, bestStart, bestEnd = r, longStart, longEnd
 		}
 	}
+	if bestScore < 0 {
+		return 0, 0, 0
+	}
 
-	return int(round(100 * bestScore))
+	return int(round(100 * bestScore)), bestStart, bestEnd
+}
+
+// byteOffset converts an offset in runes into s to an offset in bytes.
+func byteOffset(s string, runes int) int {
+	for i := range s {
+		if runes == 0 {
+			return i
+		}
+		runes--
+	}
+	return len(s)
 }
 
-func floatRatio(chrs1, chrs2 []rune) float64 {
+func floatRatio[T comparable](chrs1, chrs2 []T) float64 {
 	lenSum := len(chrs1) + len(chrs2)
 	if lenSum == 0 {
 		return 0.0
@@ -62,16 +117,33 @@ func floatRatio(chrs1, chrs2 []rune) float64 {
 // QRatio computes a score similar to Ratio, except both strings are trimmed,
 // cleansed of non-ASCII characters, and case-standardized.
 func QRatio(s1, s2 string) int {
-	return quickRatioHelper(s1, s2, true)
+	return quickRatioHelper(s1, s2, true, false)
 }
 
-// UQRatio computes a score similar to Ratio, except both strings are trimmed
-// and case-standardized.
+// UQRatio computes a score similar to Ratio, except both strings are trimmed,
+// normalized to NFC, and case-folded, so that visually identical strings
+// always score 100.
 func UQRatio(s1, s2 string) int {
-	return quickRatioHelper(s1, s2, false)
+	return quickRatioHelper(s1, s2, false, false)
 }
 
-func quickRatioHelper(s1, s2 string, asciiOnly bool) int {
+// TQRatio computes a score similar to QRatio, except non-ASCII
+// characters are transliterated rather than removed.
+func TQRatio(s1, s2 string) int {
+	return quickRatioHelper(s1, s2, true, true)
+}
+
+// unicodeProcessor makes canonically equivalent strings and strings
+// differing only in case identical.
+var unicodeProcessor = NormalizingProcessor(NFC, true)
+
+func quickRatioHelper(s1, s2 string, asciiOnly, transliterate bool) int {
+	if !asciiOnly {
+		s1, s2 = unicodeProcessor(s1), unicodeProcessor(s2)
+	}
+	if transliterate {
+		s1, s2 = Transliterate(s1), Transliterate(s2)
+	}
 	c1 := Cleanse(s1, asciiOnly)
 	c2 := Cleanse(s2, asciiOnly)
 
@@ -91,16 +163,29 @@ func quickRatioHelper(s1, s2 string, asciiOnly bool) int {
 //    Otherwise, compute TokenSortRatio and TokenSetRatio.
 // 5. Return the max of all computed ratios.
 func WRatio(s1, s2 string) int {
-	return weightedRatioHelper(s1, s2, true)
+	return weightedRatioHelper(s1, s2, true, false)
 }
 
 // UWRatio computes a score similar to WRatio, except non-ASCII
-// characters are allowed.
+// characters are allowed. Both strings are normalized to NFC and
+// case-folded before they are cleansed.
 func UWRatio(s1, s2 string) int {
-	return weightedRatioHelper(s1, s2, false)
+	return weightedRatioHelper(s1, s2, false, false)
+}
+
+// TWRatio computes a score similar to WRatio, except non-ASCII
+// characters are transliterated rather than removed.
+func TWRatio(s1, s2 string) int {
+	return weightedRatioHelper(s1, s2, true, true)
 }
 
-func weightedRatioHelper(s1, s2 string, asciiOnly bool) int {
+func weightedRatioHelper(s1, s2 string, asciiOnly, transliterate bool) int {
+	if !asciiOnly {
+		s1, s2 = unicodeProcessor(s1), unicodeProcessor(s2)
+	}
+	if transliterate {
+		s1, s2 = Transliterate(s1), Transliterate(s2)
+	}
 	c1 := Cleanse(s1, asciiOnly)
 	c2 := Cleanse(s2, asciiOnly)
 
@@ -150,44 +235,149 @@ func max(args ...float64) float64 {
 
 // TokenSortRatio computes a score similar to Ratio, except tokens
 // are sorted and (optionally) cleansed prior to comparison.
+// Optional arguments are the booleans asciiOnly and cleanse, in that
+// order; use the TokenSortRatio method of TokenOptions for further
+// options.
 func TokenSortRatio(s1, s2 string, opts ...bool) int {
-	return tokenSortRatioHelper(s1, s2, false, opts...)
+	return tokenSortRatioHelper(s1, s2, false, parseTokenOptions(opts...))
 }
 
 // PartialTokenSortRatio computes a score similar to PartialRatio, except tokens
 // are sorted and (optionally) cleansed prior to comparison.
 func PartialTokenSortRatio(s1, s2 string, opts ...bool) int {
-	return tokenSortRatioHelper(s1, s2, true, opts...)
+	return tokenSortRatioHelper(s1, s2, true, parseTokenOptions(opts...))
+}
+
+func tokenSortRatioHelper(s1, s2 string, partial bool, o *TokenOptions) int {
+	sorted1 := tokenSort(o.tokenize(s1))
+	sorted2 := tokenSort(o.tokenize(s2))
+
+	return o.ratioFunction(partial)(sorted1, sorted2)
+}
+
+// TokenOptions configures the token scorers. The scorers are methods,
+// so that a configured scorer can be passed to Extract:
+//
+//	opts := &TokenOptions{Cleanse: true, Tokenizer: WordTokenizer}
+//	matches, err := Extract(query, choices, 5, opts.TokenSetRatio)
+//
+// A nil *TokenOptions uses the defaults, those of TokenSetRatio and
+// TokenSortRatio without optional arguments.
+type TokenOptions struct {
+	// ASCIIOnly drops non-ASCII characters from the strings.
+	ASCIIOnly bool
+	// Cleanse cleanses the strings as the Cleanse function does.
+	Cleanse bool
+	// Unit selects the unit of edit distance, runes by default.
+	Unit ComparisonUnit
+	// Tokenizer replaces the default whitespace tokenization.
+	Tokenizer Tokenizer
+	// Stopwords are removed from the tokens.
+	Stopwords *Stopwords
+	// Synonyms canonicalize the strings before they are tokenized.
+	Synonyms *Synonyms
+}
+
+// TokenSortRatio is like the TokenSortRatio function with the options of o.
+func (o *TokenOptions) TokenSortRatio(s1, s2 string) int {
+	return tokenSortRatioHelper(s1, s2, false, o.orDefault())
+}
+
+// PartialTokenSortRatio is like the PartialTokenSortRatio function with
+// the options of o.
+func (o *TokenOptions) PartialTokenSortRatio(s1, s2 string) int {
+	return tokenSortRatioHelper(s1, s2, true, o.orDefault())
+}
+
+// TokenSetRatio is like the TokenSetRatio function with the options of o.
+func (o *TokenOptions) TokenSetRatio(s1, s2 string) int {
+	return tokenSetRatioHelper(s1, s2, false, o.orDefault())
+}
+
+// PartialTokenSetRatio is like the PartialTokenSetRatio function with
+// the options of o.
+func (o *TokenOptions) PartialTokenSetRatio(s1, s2 string) int {
+	return tokenSetRatioHelper(s1, s2, true, o.orDefault())
+}
+
+func (o *TokenOptions) orDefault() *TokenOptions {
+	if o == nil {
+		return &TokenOptions{}
+	}
+	return o
+}
+
+// cleansed returns a copy of the options of o with Cleanse set, for
+// the scorers that always cleanse tokens.
+func (o *TokenOptions) cleansed() *TokenOptions {
+	c := *o.orDefault()
+	c.Cleanse = true
+	return &c
 }
 
-func tokenSortRatioHelper(s1, s2 string, partial bool, opts ...bool) int {
-	asciiOnly, cleanse := false, false
+// parseTokenOptions reads the optional booleans asciiOnly and cleanse
+// of the token scorers, in that order.
+func parseTokenOptions(opts ...bool) *TokenOptions {
+	o := new(TokenOptions)
 	for i, val := range opts {
 		switch i {
 		case 0:
-			asciiOnly = val
+			o.ASCIIOnly = val
 		case 1:
-			cleanse = val
+			o.Cleanse = val
 		}
 	}
+	return o
+}
 
-	sorted1 := tokenSort(s1, asciiOnly, cleanse)
-	sorted2 := tokenSort(s2, asciiOnly, cleanse)
+// tokenize splits s into tokens. Synonyms are canonicalized before
+// anything else, so that abbreviations written with punctuation, such
+// as "&" or "Intl.", are recognized. Without a tokenizer, s is then
+// cleansed as a whole and split on whitespace. Tokens produced by a
+// tokenizer are cleansed one at a time, and tokens left empty are dropped.
+func (o *TokenOptions) tokenize(s string) []string {
+	if o.Synonyms != nil {
+		s = o.Synonyms.Canonicalize(s)
+	}
+	var tokens []string
+	if o.Tokenizer == nil {
+		tokens = strings.Fields(o.process(s))
+	} else {
+		tokens = []string{}
+		for _, token := range o.Tokenizer.Tokenize(s) {
+			if token = strings.TrimSpace(o.process(token)); token != "" {
+				tokens = append(tokens, token)
+			}
+		}
+	}
+	if o.Stopwords != nil {
+		tokens = o.Stopwords.filter(tokens)
+	}
+	return tokens
+}
 
-	if partial {
-		return PartialRatio(sorted1, sorted2)
+func (o *TokenOptions) process(s string) string {
+	if o.Cleanse {
+		return Cleanse(s, o.ASCIIOnly)
+	} else if o.ASCIIOnly {
+		return ASCIIOnly(s)
 	}
-	return Ratio(sorted1, sorted2)
+	return s
 }
 
-func tokenSort(s string, asciiOnly, cleanse bool) string {
-	if cleanse {
-		s = Cleanse(s, asciiOnly)
-	} else if asciiOnly {
-		s = ASCIIOnly(s)
+func (o *TokenOptions) ratioFunction(partial bool) func(string, string) int {
+	switch {
+	case partial && o.Unit == GraphemeUnit:
+		return GraphemePartialRatio
+	case partial:
+		return PartialRatio
+	case o.Unit == GraphemeUnit:
+		return GraphemeRatio
 	}
+	return Ratio
+}
 
-	tokens := strings.Fields(s)
+func tokenSort(tokens []string) string {
 	sort.Strings(tokens)
 	return strings.Join(tokens, " ")
 }
@@ -196,8 +386,9 @@ func tokenSort(s string, asciiOnly, cleanse bool) string {
 // them to a set, construct strings of the form
 // <sorted intersection><sorted remainder>, takes the ratios
 // of those two strings, and returns the max.
+// Optional arguments are the same as for TokenSortRatio.
 func TokenSetRatio(s1, s2 string, opts ...bool) int {
-	return tokenSetRatioHelper(s1, s2, false, opts...)
+	return tokenSetRatioHelper(s1, s2, false, parseTokenOptions(opts...))
 }
 
 // PartialTokenSetRatio extracts tokens from each input string, adds
@@ -205,34 +396,17 @@ func TokenSetRatio(s1, s2 string, opts ...bool) int {
 // <sorted intersection><sorted remainder>, takes the partial ratios
 // of those two strings, and returns the max.
 func PartialTokenSetRatio(s1, s2 string, opts ...bool) int {
-	return tokenSetRatioHelper(s1, s2, true, opts...)
+	return tokenSetRatioHelper(s1, s2, true, parseTokenOptions(opts...))
 }
 
-func tokenSetRatioHelper(s1, s2 string, partial bool, opts ...bool) int {
-	asciiOnly, cleanse := false, false
-	for i, val := range opts {
-		switch i {
-		case 0:
-			asciiOnly = val
-		case 1:
-			cleanse = val
-		}
-	}
-
-	if cleanse {
-		s1 = Cleanse(s1, asciiOnly)
-		s2 = Cleanse(s2, asciiOnly)
-	} else if asciiOnly {
-		s1 = ASCIIOnly(s1)
-		s2 = ASCIIOnly(s2)
-	}
-
-	if len(s1) == 0 || len(s2) == 0 {
+func tokenSetRatioHelper(s1, s2 string, partial bool, o *TokenOptions) int {
+	tokens1, tokens2 := o.tokenize(s1), o.tokenize(s2)
+	if len(tokens1) == 0 || len(tokens2) == 0 {
 		return 0
 	}
 
-	set1 := NewStringSet(strings.Fields(s1))
-	set2 := NewStringSet(strings.Fields(s2))
+	set1 := NewStringSet(tokens1)
+	set2 := NewStringSet(tokens2)
 	intersection := set1.Intersect(set2).ToSlice()
 	diff1to2 := set1.Difference(set2).ToSlice()
 	diff2to1 := set2.Difference(set1).ToSlice()
@@ -245,12 +419,7 @@ func tokenSetRatioHelper(s1, s2 string, partial bool, opts ...bool) int {
 	combined1to2 := strings.TrimSpace(sortedIntersect + " " + strings.Join(diff1to2, " "))
 	combined2to1 := strings.TrimSpace(sortedIntersect + " " + strings.Join(diff2to1, " "))
 
-	var ratioFunction func(string, string) int
-	if partial {
-		ratioFunction = PartialRatio
-	} else {
-		ratioFunction = Ratio
-	}
+	ratioFunction := o.ratioFunction(partial)
 
 	score := ratioFunction(sortedIntersect, combined1to2)
 	if alt1 := ratioFunction(sortedIntersect, combined2to1); alt1 > score {
@@ -71,6 +71,35 @@ func TestPartialRatio(t *testing.T) {
 	assertRatio(t, "Ratio", s5, s6, 21, r6)
 }
 
+func TestPartialRatioAlignment(t *testing.T) {
+	cases := []struct {
+		s1, s2                               string
+		srcStart, srcEnd, destStart, destEnd int
+		srcByteStart, srcByteEnd             int
+		destByteStart, destByteEnd           int
+	}{
+		{"fuzzy", "a fuzzy wuzzy bear", 0, 5, 2, 7, 0, 5, 2, 7},
+		{"a fuzzy wuzzy bear", "fuzzy", 2, 7, 0, 5, 2, 7, 0, 5},
+		{"café", "le café noir", 0, 4, 3, 7, 0, 5, 3, 8},
+		{"", "", 0, 0, 0, 0, 0, 0, 0, 0},
+	}
+	for _, c := range cases {
+		a := PartialRatioAlignment(c.s1, c.s2)
+		assertRatio(t, "PartialRatioAlignment", c.s1, c.s2, PartialRatio(c.s1, c.s2), a.Score)
+		expected := ScoreAlignment{a.Score, c.srcStart, c.srcEnd, c.destStart, c.destEnd,
+			c.srcByteStart, c.srcByteEnd, c.destByteStart, c.destByteEnd}
+		if *a != expected {
+			t.Errorf("PartialRatioAlignment(%q, %q): Expected %+v, got %+v.", c.s1, c.s2, expected, *a)
+		}
+	}
+
+	s1, s2 := "HSINCHUANG", "LSINJHUANG DISTRIC"
+	a := PartialRatioAlignment(s1, s2)
+	if r := Ratio(s1, s2[a.DestByteStart:a.DestByteEnd]); r != a.Score {
+		t.Errorf("Expected Ratio of '%v' and its aligned substring to be %v. Got %v", s1, a.Score, r)
+	}
+}
+
 func TestTokenSortRatio(t *testing.T) {
 	r1 := PartialRatio(games[1], games[0])
 	assertRatioIs100(t, "TokenSortRatio", games[1], games[0], r1)
@@ -93,6 +122,22 @@ func TestPartialTokenSetRatio(t *testing.T) {
 	assertRatioIs100(t, "PartialTokenSetRatio", games[4], games[7], r1)
 }
 
+func TestTokenOptions(t *testing.T) {
+	// the token scorers keep their signatures, and a []bool can be spread
+	var scorer func(string, string, ...bool) int = TokenSetRatio
+	flags := []bool{true, true}
+	s1, s2 := "Café, Paris", "paris cafe"
+	assertRatio(t, "TokenSetRatio", s1, s2, TokenSetRatio(s1, s2, true, true), scorer(s1, s2, flags...))
+
+	opts := &TokenOptions{ASCIIOnly: true, Cleanse: true}
+	assertRatio(t, "TokenOptions.TokenSetRatio", s1, s2, TokenSetRatio(s1, s2, true, true), opts.TokenSetRatio(s1, s2))
+	assertRatio(t, "TokenOptions.TokenSortRatio", s1, s2, TokenSortRatio(s1, s2, true, true), opts.TokenSortRatio(s1, s2))
+
+	var defaults *TokenOptions
+	assertRatio(t, "TokenOptions.TokenSetRatio", games[4], games[5], TokenSetRatio(games[4], games[5]), defaults.TokenSetRatio(games[4], games[5]))
+	assertRatio(t, "TokenOptions.PartialTokenSetRatio", games[4], games[7], PartialTokenSetRatio(games[4], games[7]), defaults.PartialTokenSetRatio(games[4], games[7]))
+}
+
 func TestQuickRatio(t *testing.T) {
 	r1 := QRatio(games[0], games[1])
 	assertRatioIs100(t, "QRatio", games[0], games[1], r1)
@@ -129,6 +174,17 @@ func TestUWRatio(t *testing.T) {
 	}
 }
 
+func TestUnicodeEquivalence(t *testing.T) {
+	s1, s2 := "Beyonce\u0301", "BEYONC\u00c9"
+	assertRatioIsNot100(t, "Ratio", s1, s2, Ratio(s1, s2))
+	assertRatioIs100(t, "UQRatio", s1, s2, UQRatio(s1, s2))
+	assertRatioIs100(t, "UWRatio", s1, s2, UWRatio(s1, s2))
+
+	s3, s4 := "STRASSE", "straße"
+	assertRatioIs100(t, "UQRatio", s3, s4, UQRatio(s3, s4))
+	assertRatioIs100(t, "UWRatio", s3, s4, UWRatio(s3, s4))
+}
+
 func TestQRatio(t *testing.T) {
 	r1 := QRatio(nonascii[0], nonascii[1])
 	if r1 != 0 {
@@ -143,6 +199,18 @@ func TestUQRatio(t *testing.T) {
 	}
 }
 
+func TestTQRatio(t *testing.T) {
+	s1, s2 := "Beyoncé", "beyonce"
+	assertRatioIsNot100(t, "QRatio", s1, s2, QRatio(s1, s2))
+	assertRatioIs100(t, "TQRatio", s1, s2, TQRatio(s1, s2))
+}
+
+func TestTWRatio(t *testing.T) {
+	s1, s2 := "Zürich Tonhalle", "zurich tonhalle"
+	assertRatioIsNot100(t, "WRatio", s1, s2, WRatio(s1, s2))
+	assertRatioIs100(t, "TWRatio", s1, s2, TWRatio(s1, s2))
+}
+
 func TestReadmeExamples(t *testing.T) {
 	s1 := "coolstring"
 	s2 := "coooolstring"
@@ -0,0 +1,239 @@
+package fuzzy
+
+import (
+	"regexp/syntax"
+	"unicode/utf8"
+)
+
+// FuzzyLimits bounds the edits allowed in a match of a FuzzyRegexp.
+// An insertion is an extra rune in the text, a deletion a rune of
+// the expression missing from the text, and a substitution a rune of
+// the text in place of one of the expression. The cost of a match is
+// its number of edits.
+type FuzzyLimits struct {
+	MaxCost          int
+	MaxInsertions    int
+	MaxDeletions     int
+	MaxSubstitutions int
+}
+
+// Within returns limits allowing up to k edits of any kind.
+func Within(k int) FuzzyLimits {
+	return FuzzyLimits{MaxCost: k, MaxInsertions: k, MaxDeletions: k, MaxSubstitutions: k}
+}
+
+// FuzzyRegexp is a regular expression matched approximately, like
+// agrep and TRE do: a substring of the text matches if it can be turned
+// into a string matching the expression within the edits allowed by
+// its limits. For instance, "2O24-1O-17" matches \d{4}-\d{2}-\d{2}
+// with two substitutions.
+//
+// Every start position is tried and matches are simulated on all edit
+// counts at once, so matching takes time quadratic in the length of the
+// text; FuzzyRegexp is meant for short texts such as log lines or
+// fields rather than whole documents.
+type FuzzyRegexp struct {
+	expr   string
+	prog   *syntax.Prog
+	limits FuzzyLimits
+}
+
+// FuzzyMatch is a match of a FuzzyRegexp: the substring [Start,End) of
+// the text, in runes, or [ByteStart,ByteEnd) in bytes, and the edits
+// needed for it to match the expression.
+type FuzzyMatch struct {
+	Start, End                           int
+	ByteStart, ByteEnd                   int
+	Cost                                 int
+	Insertions, Deletions, Substitutions int
+}
+
+// CompileFuzzy parses a regular expression with the syntax of the regexp
+// package and returns a FuzzyRegexp matching it within the given limits.
+func CompileFuzzy(expr string, limits FuzzyLimits) (*FuzzyRegexp, error) {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return nil, err
+	}
+	return &FuzzyRegexp{expr: expr, prog: prog, limits: limits}, nil
+}
+
+// MustCompileFuzzy is like CompileFuzzy but panics if the expression
+// cannot be parsed.
+func MustCompileFuzzy(expr string, limits FuzzyLimits) *FuzzyRegexp {
+	re, err := CompileFuzzy(expr, limits)
+	if err != nil {
+		panic(`fuzzy: CompileFuzzy(` + expr + `): ` + err.Error())
+	}
+	return re
+}
+
+// String returns the source text of the expression.
+func (re *FuzzyRegexp) String() string {
+	return re.expr
+}
+
+// MatchString reports whether s contains a match of re.
+func (re *FuzzyRegexp) MatchString(s string) bool {
+	return re.Find(s) != nil
+}
+
+// Find returns the first match of re in s (see FindAll), or nil if
+// there is none.
+func (re *FuzzyRegexp) Find(s string) *FuzzyMatch {
+	if matches := re.FindAll(s); len(matches) > 0 {
+		return &matches[0]
+	}
+	return nil
+}
+
+// FindAll returns the matches of re in s. Matches do not overlap and are
+// chosen from left to right: of overlapping candidates the one with the
+// lowest cost is kept, and the earliest of equally good ones. From a
+// given start, the match with the lowest cost is preferred, then the
+// longest. Empty matches are only reported if the expression matches
+// the empty string without edits.
+func (re *FuzzyRegexp) FindAll(s string) []FuzzyMatch {
+	chrs := []rune(s)
+	byteOffsets := make([]int, len(chrs)+1)
+	for i, chr := range chrs {
+		byteOffsets[i+1] = byteOffsets[i] + utf8.RuneLen(chr)
+	}
+
+	matches := []FuzzyMatch{}
+	var pending *FuzzyMatch
+	lastEnd := 0
+	for start := 0; start <= len(chrs); start++ {
+		if start < lastEnd {
+			continue
+		}
+		candidate := re.matchAt(chrs, start)
+		if candidate == nil || (candidate.Start == candidate.End && candidate.Cost > 0) {
+			continue
+		}
+		candidate.ByteStart, candidate.ByteEnd = byteOffsets[candidate.Start], byteOffsets[candidate.End]
+		switch {
+		case pending == nil:
+			pending = candidate
+		case candidate.Start < pending.End:
+			if candidate.Cost < pending.Cost {
+				pending = candidate
+			}
+		default:
+			matches = append(matches, *pending)
+			lastEnd = pending.End
+			pending = candidate
+		}
+	}
+	if pending != nil {
+		matches = append(matches, *pending)
+	}
+	return matches
+}
+
+// fuzzyThread is a state of the simulation: an instruction of the
+// program and the edits made to reach it.
+type fuzzyThread struct {
+	pc            uint32
+	ins, del, sub int
+}
+
+func (t fuzzyThread) cost() int {
+	return t.ins + t.del + t.sub
+}
+
+// matchAt returns the best match of re starting at chrs[start], or nil.
+func (re *FuzzyRegexp) matchAt(chrs []rune, start int) *FuzzyMatch {
+	var best *FuzzyMatch
+	threads := []fuzzyThread{{pc: uint32(re.prog.Start)}}
+	for pos := start; len(threads) > 0; pos++ {
+		before, after := rune(-1), rune(-1)
+		if pos > 0 {
+			before = chrs[pos-1]
+		}
+		if pos < len(chrs) {
+			after = chrs[pos]
+		}
+		threads = re.closure(threads, before, after)
+
+		var next []fuzzyThread
+		for _, t := range threads {
+			inst := &re.prog.Inst[t.pc]
+			if inst.Op == syntax.InstMatch {
+				if best == nil || t.cost() < best.Cost || (t.cost() == best.Cost && pos > best.End) {
+					best = &FuzzyMatch{Start: start, End: pos, Cost: t.cost(),
+						Insertions: t.ins, Deletions: t.del, Substitutions: t.sub}
+				}
+				continue
+			}
+			if pos == len(chrs) {
+				continue
+			}
+			if inst.MatchRune(chrs[pos]) {
+				next = append(next, fuzzyThread{inst.Out, t.ins, t.del, t.sub})
+			} else {
+				next = append(next, fuzzyThread{inst.Out, t.ins, t.del, t.sub + 1})
+			}
+			next = append(next, fuzzyThread{t.pc, t.ins + 1, t.del, t.sub})
+		}
+		threads = re.prune(next)
+	}
+	return best
+}
+
+// closure follows the instructions that consume no rune from threads,
+// including deletions, which skip an instruction consuming a rune.
+// The threads returned are at instructions consuming a rune or at
+// InstMatch.
+func (re *FuzzyRegexp) closure(threads []fuzzyThread, before, after rune) []fuzzyThread {
+	seen := make(map[fuzzyThread]bool)
+	var result []fuzzyThread
+	stack := append([]fuzzyThread(nil), threads...)
+	for len(stack) > 0 {
+		t := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[t] || !re.allows(t) {
+			continue
+		}
+		seen[t] = true
+		inst := &re.prog.Inst[t.pc]
+		switch inst.Op {
+		case syntax.InstAlt, syntax.InstAltMatch:
+			stack = append(stack, fuzzyThread{inst.Out, t.ins, t.del, t.sub}, fuzzyThread{inst.Arg, t.ins, t.del, t.sub})
+		case syntax.InstCapture, syntax.InstNop:
+			stack = append(stack, fuzzyThread{inst.Out, t.ins, t.del, t.sub})
+		case syntax.InstEmptyWidth:
+			if inst.MatchEmptyWidth(before, after) {
+				stack = append(stack, fuzzyThread{inst.Out, t.ins, t.del, t.sub})
+			}
+		case syntax.InstMatch:
+			result = append(result, t)
+		case syntax.InstRune, syntax.InstRune1, syntax.InstRuneAny, syntax.InstRuneAnyNotNL:
+			result = append(result, t)
+			stack = append(stack, fuzzyThread{inst.Out, t.ins, t.del + 1, t.sub})
+		}
+	}
+	return result
+}
+
+// prune drops duplicate threads and threads beyond the limits.
+func (re *FuzzyRegexp) prune(threads []fuzzyThread) []fuzzyThread {
+	seen := make(map[fuzzyThread]bool)
+	result := threads[:0]
+	for _, t := range threads {
+		if !seen[t] && re.allows(t) {
+			seen[t] = true
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+func (re *FuzzyRegexp) allows(t fuzzyThread) bool {
+	return t.cost() <= re.limits.MaxCost && t.ins <= re.limits.MaxInsertions &&
+		t.del <= re.limits.MaxDeletions && t.sub <= re.limits.MaxSubstitutions
+}
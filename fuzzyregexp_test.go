@@ -0,0 +1,71 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyRegexpFindAll(t *testing.T) {
+	date := MustCompileFuzzy(`\d{4}-\d{2}-\d{2}`, Within(2))
+	cases := []struct {
+		re       *FuzzyRegexp
+		s        string
+		expected []FuzzyMatch
+	}{
+		{date, "ts=2O24-1O-17 level=error", []FuzzyMatch{{3, 13, 3, 13, 2, 0, 0, 2}}},
+		{date, "from 2024-10-17 to 2024-1017", []FuzzyMatch{
+			{5, 15, 5, 15, 0, 0, 0, 0},
+			{19, 28, 19, 28, 1, 0, 1, 0},
+		}},
+		{date, "no dates here", []FuzzyMatch{}},
+		{MustCompileFuzzy(`café`, Within(1)), "le cafe noir", []FuzzyMatch{{3, 7, 3, 7, 1, 0, 0, 1}}},
+		{MustCompileFuzzy(`(?i)order-\d+`, Within(1)), "Order_1234, ORDER-99", []FuzzyMatch{
+			{0, 10, 0, 10, 1, 0, 0, 1},
+			{12, 20, 12, 20, 0, 0, 0, 0},
+		}},
+		{MustCompileFuzzy(`^abc$`, Within(1)), "abxc", []FuzzyMatch{{0, 4, 0, 4, 1, 1, 0, 0}}},
+		{MustCompileFuzzy(`^abc$`, Within(1)), "xabcx", []FuzzyMatch{}},
+		{MustCompileFuzzy(`x*`, Within(1)), "ab", []FuzzyMatch{{0, 0, 0, 0, 0, 0, 0, 0}, {1, 1, 1, 1, 0, 0, 0, 0}, {2, 2, 2, 2, 0, 0, 0, 0}}},
+	}
+	for _, c := range cases {
+		if actual := c.re.FindAll(c.s); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%v.FindAll(%q): Expected %v, got %v.", c.re, c.s, c.expected, actual)
+		}
+	}
+}
+
+func TestFuzzyRegexpLimits(t *testing.T) {
+	cases := []struct {
+		limits   FuzzyLimits
+		s        string
+		expected bool
+	}{
+		{Within(0), "2O24", false},
+		{Within(1), "2O24", true},
+		{FuzzyLimits{MaxCost: 1, MaxSubstitutions: 1}, "2O24", true},
+		{FuzzyLimits{MaxCost: 1, MaxInsertions: 1, MaxDeletions: 1}, "2O24", false},
+		{FuzzyLimits{MaxCost: 1, MaxInsertions: 1}, "20024", true},
+		{FuzzyLimits{MaxCost: 1, MaxDeletions: 1}, "024", true},
+		{FuzzyLimits{MaxCost: 1, MaxSubstitutions: 1}, "024", false},
+	}
+	for _, c := range cases {
+		re := MustCompileFuzzy(`^\d{4}$|\b\d{4}\b`, c.limits)
+		if actual := re.MatchString(c.s); actual != c.expected {
+			t.Errorf("MatchString(%q) with %+v: Expected %v, got %v.", c.s, c.limits, c.expected, actual)
+		}
+	}
+}
+
+func TestFuzzyRegexpFind(t *testing.T) {
+	re := MustCompileFuzzy(`ID-[0-9]{3}`, Within(1))
+	if m := re.Find("ticket lD-042 reopened"); m == nil || m.Start != 7 || m.End != 13 || m.Cost != 1 {
+		t.Errorf("Find: Expected a match of cost 1 at [7,13), got %+v.", m)
+	}
+	if m := re.Find("nothing"); m != nil {
+		t.Errorf("Find: Expected no match, got %+v.", m)
+	}
+
+	if _, err := CompileFuzzy(`a(b`, Within(1)); err == nil {
+		t.Errorf("CompileFuzzy: Expected an error for an invalid expression.")
+	}
+}
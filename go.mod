@@ -0,0 +1,3 @@
+module github.com/paul-mannino/go-fuzzywuzzy
+
+go 1.18
@@ -0,0 +1,213 @@
+package fuzzy
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// ComparisonUnit selects what counts as a single unit of
+// edit distance when two strings are compared.
+type ComparisonUnit int
+
+const (
+	// RuneUnit compares strings rune by rune.
+	RuneUnit ComparisonUnit = iota
+	// GraphemeUnit compares strings by extended grapheme cluster, so that
+	// a flag emoji or a letter with stacked combining marks counts as a
+	// single user-perceived character.
+	GraphemeUnit
+)
+
+// graphemeRuneBase is the first of the synthetic runes standing in for
+// grapheme clusters made up of more than one rune. It lies beyond
+// unicode.MaxRune, so it cannot collide with a real character.
+const graphemeRuneBase = unicode.MaxRune + 1
+
+// GraphemeRatio computes a score similar to Ratio, except the unit of
+// edit distance is an extended grapheme cluster rather than a rune.
+func GraphemeRatio(s1, s2 string) int {
+	chrs1, chrs2 := graphemeRunes(s1, s2)
+	return int(round(100 * floatRatio(chrs1, chrs2)))
+}
+
+// GraphemePartialRatio computes a score similar to PartialRatio, except
+// the unit of edit distance is an extended grapheme cluster rather than
+// a rune, so that the best matching substring never splits a cluster.
+func GraphemePartialRatio(s1, s2 string) int {
+	chrs1, chrs2 := graphemeRunes(s1, s2)
+	return partialRatio(chrs1, chrs2)
+}
+
+// Graphemes splits s into extended grapheme clusters following the
+// main rules of Unicode UAX #29: CR LF pairs, Hangul syllable sequences,
+// combining and spacing marks, emoji modifier and ZWJ sequences,
+// and regional indicator pairs are kept together.
+func Graphemes(s string) []string {
+	clusters := []string{}
+	start := 0
+	var prev rune
+	riCount := 0
+	emojiSeq := false
+	for i, r := range s {
+		if i > 0 && isGraphemeBoundary(prev, r, riCount, emojiSeq) {
+			clusters = append(clusters, s[start:i])
+			start = i
+			riCount = 0
+			emojiSeq = false
+		}
+
+		if isRegionalIndicator(r) {
+			riCount++
+		}
+		if unicode.Is(extendedPictographic, r) {
+			emojiSeq = true
+		} else if emojiSeq && !isGraphemeExtend(r) && r != zeroWidthJoiner {
+			emojiSeq = false
+		}
+		prev = r
+	}
+	if start < len(s) {
+		clusters = append(clusters, s[start:])
+	}
+	return clusters
+}
+
+const zeroWidthJoiner = '\u200d'
+
+func isGraphemeBoundary(prev, r rune, riCount int, emojiSeq bool) bool {
+	switch {
+	case prev == '\r' && r == '\n':
+		return false
+	case isGraphemeControl(prev) || isGraphemeControl(r):
+		return true
+	case hangulJoins(prev, r):
+		return false
+	case isGraphemeExtend(r) || r == zeroWidthJoiner || unicode.Is(unicode.Mc, r):
+		return false
+	case prev == zeroWidthJoiner && emojiSeq && unicode.Is(extendedPictographic, r):
+		return false
+	case isRegionalIndicator(prev) && isRegionalIndicator(r):
+		return riCount%2 == 0
+	}
+	return true
+}
+
+func isGraphemeControl(r rune) bool {
+	return r == '\r' || r == '\n' ||
+		(unicode.IsControl(r) || unicode.Is(unicode.Zl, r) || unicode.Is(unicode.Zp, r)) ||
+		(unicode.Is(unicode.Cf, r) && r != zeroWidthJoiner && r != '\u200c' && !isTag(r))
+}
+
+func isGraphemeExtend(r rune) bool {
+	return unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) ||
+		r == '\u200c' || isTag(r) || (r >= 0x1F3FB && r <= 0x1F3FF)
+}
+
+func isTag(r rune) bool {
+	return r >= 0xE0020 && r <= 0xE007F
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+func hangulJoins(prev, r rune) bool {
+	isL := func(r rune) bool { return (r >= 0x1100 && r <= 0x115F) || (r >= 0xA960 && r <= 0xA97C) }
+	isV := func(r rune) bool { return (r >= 0x1160 && r <= 0x11A7) || (r >= 0xD7B0 && r <= 0xD7C6) }
+	isT := func(r rune) bool { return (r >= 0x11A8 && r <= 0x11FF) || (r >= 0xD7CB && r <= 0xD7FB) }
+	isSyllable := r >= hangulSBase && r < hangulSBase+hangulSCount
+	prevIsSyllable := prev >= hangulSBase && prev < hangulSBase+hangulSCount
+	prevIsLV := prevIsSyllable && (prev-hangulSBase)%hangulTCount == 0
+
+	switch {
+	case isL(prev):
+		return isL(r) || isV(r) || isSyllable
+	case isV(prev) || prevIsLV:
+		return isV(r) || isT(r)
+	case isT(prev) || prevIsSyllable:
+		return isT(r)
+	}
+	return false
+}
+
+// graphemeRunes converts both strings to rune slices with one element
+// per grapheme cluster. Single-rune clusters are kept as they are, and
+// each distinct multi-rune cluster is replaced by the same synthetic
+// rune in both slices, so the rune-based algorithms can compare them.
+func graphemeRunes(s1, s2 string) ([]rune, []rune) {
+	ids := make(map[string]rune)
+	convert := func(s string) []rune {
+		clusters := Graphemes(s)
+		chrs := make([]rune, len(clusters))
+		for i, cluster := range clusters {
+			if utf8.RuneCountInString(cluster) == 1 {
+				chrs[i], _ = utf8.DecodeRuneInString(cluster)
+				continue
+			}
+			id, ok := ids[cluster]
+			if !ok {
+				id = graphemeRuneBase + rune(len(ids))
+				ids[cluster] = id
+			}
+			chrs[i] = id
+		}
+		return chrs
+	}
+	return convert(s1), convert(s2)
+}
+
+// extendedPictographic approximates the Extended_Pictographic property,
+// which the standard library does not provide.
+var extendedPictographic = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x00A9, 0x00AE, 5},
+		{0x203C, 0x2049, 13},
+		{0x2122, 0x2139, 23},
+		{0x2194, 0x2199, 1},
+		{0x21A9, 0x21AA, 1},
+		{0x231A, 0x231B, 1},
+		{0x2328, 0x23CF, 167},
+		{0x23E9, 0x23F3, 1},
+		{0x23F8, 0x23FA, 1},
+		{0x24C2, 0x25AA, 232},
+		{0x25AB, 0x25B6, 11},
+		{0x25C0, 0x25FB, 59},
+		{0x25FC, 0x25FE, 1},
+		{0x2600, 0x27BF, 1},
+		{0x2934, 0x2935, 1},
+		{0x2B05, 0x2B07, 1},
+		{0x2B1B, 0x2B1C, 1},
+		{0x2B50, 0x2B55, 5},
+		{0x3030, 0x303D, 13},
+		{0x3297, 0x3299, 2},
+	},
+	R32: []unicode.Range32{
+		{0x1F000, 0x1F0FF, 1},
+		{0x1F10D, 0x1F10F, 1},
+		{0x1F12F, 0x1F16C, 61},
+		{0x1F16D, 0x1F171, 1},
+		{0x1F17E, 0x1F17F, 1},
+		{0x1F18E, 0x1F191, 3},
+		{0x1F192, 0x1F19A, 1},
+		{0x1F1AD, 0x1F1E5, 1},
+		{0x1F201, 0x1F20F, 1},
+		{0x1F21A, 0x1F22F, 21},
+		{0x1F232, 0x1F23A, 1},
+		{0x1F23C, 0x1F23F, 1},
+		{0x1F249, 0x1F3FA, 1},
+		{0x1F400, 0x1F53D, 1},
+		{0x1F546, 0x1F64F, 1},
+		{0x1F680, 0x1F6FF, 1},
+		{0x1F774, 0x1F77F, 1},
+		{0x1F7D5, 0x1F7FF, 1},
+		{0x1F80C, 0x1F80F, 1},
+		{0x1F848, 0x1F84F, 1},
+		{0x1F85A, 0x1F85F, 1},
+		{0x1F888, 0x1F88F, 1},
+		{0x1F8AE, 0x1F8FF, 1},
+		{0x1F90C, 0x1F93A, 1},
+		{0x1F93C, 0x1F945, 1},
+		{0x1F947, 0x1FAFF, 1},
+		{0x1FC00, 0x1FFFD, 1},
+	},
+}
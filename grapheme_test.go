@@ -0,0 +1,62 @@
+package fuzzy
+
+import (
+	"testing"
+)
+
+var graphemesData = []struct {
+	input string
+	want  []string
+}{
+	{"abc", []string{"a", "b", "c"}},
+	{"e\u0301\u0323x", []string{"e\u0301\u0323", "x"}},
+	{"\U0001F1FA\U0001F1F8\U0001F1EB\U0001F1F7", []string{"\U0001F1FA\U0001F1F8", "\U0001F1EB\U0001F1F7"}},
+	{"\U0001F44D\U0001F3FD!", []string{"\U0001F44D\U0001F3FD", "!"}},
+	{"\U0001F468\u200d\U0001F469\u200d\U0001F467", []string{"\U0001F468\u200d\U0001F469\u200d\U0001F467"}},
+	{"각가", []string{"각", "가"}},
+	{"a\r\nb", []string{"a", "\r\n", "b"}},
+	{"", []string{}},
+}
+
+func TestGraphemes(t *testing.T) {
+	for _, testCase := range graphemesData {
+		actual := Graphemes(testCase.input)
+		if len(actual) != len(testCase.want) {
+			t.Errorf("Graphemes %+q: Expected %+q, got %+q.", testCase.input, testCase.want, actual)
+			continue
+		}
+		for i := range actual {
+			if actual[i] != testCase.want[i] {
+				t.Errorf("Graphemes %+q: Expected %+q, got %+q.", testCase.input, testCase.want, actual)
+				break
+			}
+		}
+	}
+}
+
+func TestGraphemeRatio(t *testing.T) {
+	s1, s2 := "dan\U0001F1FA\U0001F1F8", "dan\U0001F1EB\U0001F1F7"
+	assertRatio(t, "Ratio", s1, s2, 60, Ratio(s1, s2))
+	assertRatio(t, "GraphemeRatio", s1, s2, 75, GraphemeRatio(s1, s2))
+
+	s3, s4 := "zoe\u0308", "zoe"
+	assertRatio(t, "Ratio", s3, s4, 86, Ratio(s3, s4))
+	assertRatio(t, "GraphemeRatio", s3, s4, 67, GraphemeRatio(s3, s4))
+}
+
+func TestGraphemePartialRatio(t *testing.T) {
+	s1, s2 := "e\u0301", "cafe\u0301 au lait"
+	assertRatioIs100(t, "GraphemePartialRatio", s1, s2, GraphemePartialRatio(s1, s2))
+
+	s3, s4 := "e", "cafe\u0301"
+	assertRatioIs100(t, "PartialRatio", s3, s4, PartialRatio(s3, s4))
+	assertRatioIsNot100(t, "GraphemePartialRatio", s3, s4, GraphemePartialRatio(s3, s4))
+}
+
+func TestGraphemeTokenOptions(t *testing.T) {
+	s1, s2 := "\U0001F1FA\U0001F1F8 team", "team \U0001F1EB\U0001F1F7"
+	assertRatio(t, "TokenSortRatio", s1, s2, 71, TokenSortRatio(s1, s2, false, false))
+	assertRatio(t, "TokenSortRatio", s1, s2, 83, (&TokenOptions{Unit: GraphemeUnit}).TokenSortRatio(s1, s2))
+	assertRatio(t, "TokenSetRatio", s1, s2, 73, TokenSetRatio(s1, s2))
+	assertRatio(t, "TokenSetRatio", s1, s2, 83, (&TokenOptions{Unit: GraphemeUnit}).TokenSetRatio(s1, s2))
+}
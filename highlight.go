@@ -0,0 +1,142 @@
+package fuzzy
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// Highlighter renders a string with the parts matching a query marked,
+// such as a choice returned by Extract. Characters are matched along the
+// same matching blocks Ratio and PartialRatio are computed from, and
+// each run of matched characters is enclosed in Open and Close.
+type Highlighter struct {
+	Open, Close string
+	// Escape, if set, is applied to the text between markers,
+	// such as html.EscapeString for HTML output.
+	Escape func(string) string
+	// Tokens highlights whole whitespace-separated tokens whose
+	// cleansed form is a token of the cleansed query, rather than
+	// single characters.
+	Tokens bool
+	// MinLength is the length in runes of the shortest run of
+	// matched characters highlighted, to leave out letters
+	// matching by chance.
+	MinLength int
+	// CaseSensitive disables the case folding of characters before
+	// they are matched, which is done by default as Extract compares
+	// strings processed into lowercase.
+	CaseSensitive bool
+}
+
+// Highlighters for terminals, HTML pages and Markdown.
+var (
+	ANSIHighlighter     = &Highlighter{Open: "\x1b[1;31m", Close: "\x1b[0m"}
+	HTMLHighlighter     = &Highlighter{Open: "<mark>", Close: "</mark>", Escape: html.EscapeString}
+	MarkdownHighlighter = &Highlighter{Open: "**", Close: "**"}
+)
+
+// Highlight returns s with the parts matching query marked.
+func (h *Highlighter) Highlight(query, s string) string {
+	chrs := []rune(s)
+	var marked []bool
+	if h.Tokens {
+		marked = h.markTokens(query, chrs)
+	} else {
+		marked = h.markRunes(query, chrs)
+	}
+
+	escape := h.Escape
+	if escape == nil {
+		escape = func(s string) string { return s }
+	}
+	var b strings.Builder
+	for start := 0; start < len(chrs); {
+		end := start + 1
+		for end < len(chrs) && marked[end] == marked[start] {
+			end++
+		}
+		if marked[start] {
+			b.WriteString(h.Open)
+			b.WriteString(escape(string(chrs[start:end])))
+			b.WriteString(h.Close)
+		} else {
+			b.WriteString(escape(string(chrs[start:end])))
+		}
+		start = end
+	}
+	return b.String()
+}
+
+func (h *Highlighter) markRunes(query string, chrs []rune) []bool {
+	queryChrs := []rune(query)
+	chrs2 := chrs
+	if !h.CaseSensitive {
+		queryChrs, chrs2 = foldRunes(queryChrs), foldRunes(chrs)
+	}
+	marked := make([]bool, len(chrs))
+	for _, block := range getMatchingBlocks(queryChrs, chrs2) {
+		if block.length == 0 || block.length < h.MinLength {
+			continue
+		}
+		for i := block.dpos; i < block.dpos+block.length; i++ {
+			marked[i] = true
+		}
+	}
+	return marked
+}
+
+func (h *Highlighter) markTokens(query string, chrs []rune) []bool {
+	process := func(s string) string {
+		if h.CaseSensitive {
+			return StripPunctuation(s)
+		}
+		return Cleanse(s, false)
+	}
+	queryTokens := NewStringSet(strings.Fields(process(query)))
+	marked := make([]bool, len(chrs))
+	for start := 0; start < len(chrs); {
+		if unicode.IsSpace(chrs[start]) {
+			start++
+			continue
+		}
+		end := start + 1
+		for end < len(chrs) && !unicode.IsSpace(chrs[end]) {
+			end++
+		}
+		tokens := strings.Fields(process(string(chrs[start:end])))
+		if len(tokens) > 0 && end-start >= h.MinLength && containsAll(queryTokens, tokens) {
+			for i := start; i < end; i++ {
+				marked[i] = true
+			}
+		}
+		start = end
+	}
+	return marked
+}
+
+// containsAll reports whether all tokens are in the set.
+func containsAll(set *StringSet, tokens []string) bool {
+	for _, token := range tokens {
+		if !set.Contains(token) {
+			return false
+		}
+	}
+	return true
+}
+
+// foldRunes lowercases runes one by one, keeping their positions.
+func foldRunes(chrs []rune) []rune {
+	folded := make([]rune, len(chrs))
+	for i, chr := range chrs {
+		folded[i] = unicode.ToLower(chr)
+	}
+	return folded
+}
+
+// Highlight returns the match with the parts matching query marked by h.
+// query is the query passed to Extract; it is matched against the match
+// as is, before any processing.
+func (m *MatchPair) Highlight(query string, h *Highlighter) string {
+	return h.Highlight(query, m.Match)
+}
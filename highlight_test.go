@@ -0,0 +1,60 @@
+package fuzzy
+
+import "testing"
+
+func TestHighlight(t *testing.T) {
+	custom := &Highlighter{Open: "[", Close: "]"}
+	cases := []struct {
+		h        *Highlighter
+		query, s string
+		expected string
+	}{
+		{custom, "new york", "New York Mets", "[New York] Mets"},
+		{custom, "new york", "new york", "[new york]"},
+		{custom, "abc", "xyz", "xyz"},
+		{custom, "", "xyz", "xyz"},
+		{custom, "abc", "", ""},
+		{&Highlighter{Open: "[", Close: "]", CaseSensitive: true}, "new york", "New York", "N[ew ]Y[ork]"},
+		{&Highlighter{Open: "[", Close: "]", CaseSensitive: true}, "new york", "New york", "N[ew york]"},
+		{custom, "jonathon", "Jonathan Smith", "[Jonath]a[n] Smith"},
+		{&Highlighter{Open: "[", Close: "]", MinLength: 2}, "jonathon", "Jonathan Smith", "[Jonath]an Smith"},
+		{ANSIHighlighter, "mets", "New York Mets", "New York \x1b[1;31mMets\x1b[0m"},
+		{HTMLHighlighter, "fish", "Fish & Chips", "<mark>Fish</mark> &amp; Chips"},
+		{MarkdownHighlighter, "chips", "Fish & Chips", "Fish & **Chips**"},
+	}
+	for _, c := range cases {
+		if actual := c.h.Highlight(c.query, c.s); actual != c.expected {
+			t.Errorf("Highlight(%q, %q): Expected %q, got %q.", c.query, c.s, c.expected, actual)
+		}
+	}
+}
+
+func TestHighlightTokens(t *testing.T) {
+	h := &Highlighter{Open: "<", Close: ">", Tokens: true}
+	cases := []struct {
+		query, s string
+		expected string
+	}{
+		{"york new", "New York Mets", "<New> <York> Mets"},
+		{"yankees", "New York Mets", "New York Mets"},
+		{"fish chips", "Fish, Chips & Peas", "<Fish,> <Chips> & Peas"},
+		{"new", "  new  ", "  <new>  "},
+	}
+	for _, c := range cases {
+		if actual := h.Highlight(c.query, c.s); actual != c.expected {
+			t.Errorf("Highlight(%q, %q): Expected %q, got %q.", c.query, c.s, c.expected, actual)
+		}
+	}
+}
+
+func TestMatchPairHighlight(t *testing.T) {
+	query := "new york mets"
+	matches, err := Extract(query, []string{"Atlanta Braves", "New York Mets", "New York Yankees"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "<mark>New York Mets</mark>"
+	if actual := matches[0].Highlight(query, HTMLHighlighter); actual != expected {
+		t.Errorf("Highlight: Expected %q, got %q.", expected, actual)
+	}
+}
@@ -0,0 +1,99 @@
+package fuzzy
+
+// jaroWinklerPrefixScale is the weight Jaro-Winkler gives to each
+// character of a common prefix, up to jaroWinklerMaxPrefix characters.
+const (
+	jaroWinklerPrefixScale = 0.1
+	jaroWinklerMaxPrefix   = 4
+)
+
+// JaroRatio computes a score of how close two strings are based on
+// their Jaro similarity, which counts the characters the strings
+// have in common near the same positions and the transpositions among
+// them. It suits short strings such as names better than Ratio.
+// Returns an integer score [0,100], higher score indicates
+// that strings are closer.
+func JaroRatio(s1, s2 string) int {
+	return int(round(100 * JaroSimilarity(s1, s2)))
+}
+
+// JaroWinklerRatio computes a score similar to JaroRatio, except
+// strings sharing a prefix score higher (see JaroWinklerSimilarity).
+func JaroWinklerRatio(s1, s2 string) int {
+	return int(round(100 * JaroWinklerSimilarity(s1, s2)))
+}
+
+// JaroSimilarity computes the Jaro similarity of two strings in [0,1].
+// Two empty strings have a similarity of 0, as they do under Ratio.
+func JaroSimilarity(s1, s2 string) float64 {
+	return jaroSimilarity([]rune(s1), []rune(s2))
+}
+
+// JaroWinklerSimilarity computes the Jaro-Winkler similarity of two strings
+// in [0,1]: their Jaro similarity, raised by a tenth of the remaining
+// distance to 1 for each character of a common prefix of up to four.
+func JaroWinklerSimilarity(s1, s2 string) float64 {
+	chrs1, chrs2 := []rune(s1), []rune(s2)
+	sim := jaroSimilarity(chrs1, chrs2)
+
+	prefix := 0
+	for prefix < min(jaroWinklerMaxPrefix, min(len(chrs1), len(chrs2))) && chrs1[prefix] == chrs2[prefix] {
+		prefix++
+	}
+	return sim + float64(prefix)*jaroWinklerPrefixScale*(1-sim)
+}
+
+func jaroSimilarity(chrs1, chrs2 []rune) float64 {
+	if len(chrs1) == 0 || len(chrs2) == 0 {
+		return 0
+	}
+
+	// characters match if equal and no further apart than the window
+	window := len(chrs1)
+	if len(chrs2) > window {
+		window = len(chrs2)
+	}
+	window = window/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	matched1 := make([]bool, len(chrs1))
+	matched2 := make([]bool, len(chrs2))
+	matches := 0
+	for i, c := range chrs1 {
+		lo, hi := i-window, min(i+window+1, len(chrs2))
+		if lo < 0 {
+			lo = 0
+		}
+		for j := lo; j < hi; j++ {
+			if !matched2[j] && chrs2[j] == c {
+				matched1[i], matched2[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	// matched characters out of order count as half a transposition
+	transpositions := 0
+	j := 0
+	for i, c := range chrs1 {
+		if !matched1[i] {
+			continue
+		}
+		for !matched2[j] {
+			j++
+		}
+		if c != chrs2[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	return (m/float64(len(chrs1)) + m/float64(len(chrs2)) + (m-float64(transpositions)/2)/m) / 3
+}
@@ -0,0 +1,35 @@
+package fuzzy
+
+import (
+	"math"
+	"testing"
+)
+
+var jaroData = [][]interface{}{
+	{"MARTHA", "MARHTA", 0.944, 0.961},
+	{"DWAYNE", "DUANE", 0.822, 0.840},
+	{"DIXON", "DICKSONX", 0.767, 0.813},
+	{"JELLYFISH", "SMELLYFISH", 0.896, 0.896},
+	{"same", "same", 1.0, 1.0},
+	{"abc", "xyz", 0.0, 0.0},
+	{"", "abc", 0.0, 0.0},
+	{"", "", 0.0, 0.0},
+}
+
+func TestJaroSimilarity(t *testing.T) {
+	for _, testCase := range jaroData {
+		s1, s2 := testCase[0].(string), testCase[1].(string)
+		if actual := JaroSimilarity(s1, s2); math.Abs(actual-testCase[2].(float64)) > 0.001 {
+			t.Errorf("JaroSimilarity %v %v: Expected %v, got %v.", s1, s2, testCase[2], actual)
+		}
+		if actual := JaroWinklerSimilarity(s1, s2); math.Abs(actual-testCase[3].(float64)) > 0.001 {
+			t.Errorf("JaroWinklerSimilarity %v %v: Expected %v, got %v.", s1, s2, testCase[3], actual)
+		}
+	}
+}
+
+func TestJaroRatio(t *testing.T) {
+	assertRatio(t, "JaroRatio", "MARTHA", "MARHTA", 94, JaroRatio("MARTHA", "MARHTA"))
+	assertRatio(t, "JaroWinklerRatio", "MARTHA", "MARHTA", 96, JaroWinklerRatio("MARTHA", "MARHTA"))
+	assertRatio(t, "JaroWinklerRatio", "Zoë", "Zoe", 82, JaroWinklerRatio("Zoë", "Zoe"))
+}
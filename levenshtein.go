@@ -38,7 +38,7 @@ func findEditOps(s1, s2 string) []levEditOp {
 	return findEditOpsHelper(chrs1, len1, chrs2, len2)
 }
 
-func findEditOpsHelper(chrs1 []rune, len1 int, chrs2 []rune, len2 int) []levEditOp {
+func findEditOpsHelper[T comparable](chrs1 []T, len1 int, chrs2 []T, len2 int) []levEditOp {
 	p1, p2 := 0, 0
 	len1o := 0
 	for len1 > 0 && len2 > 0 && chrs1[p1] == chrs2[p2] {
@@ -102,7 +102,7 @@ func findEditOpsHelper(chrs1 []rune, len1 int, chrs2 []rune, len2 int) []levEdit
 	return editOpsFromCostMatrix(len1, chrs1, p1, len1o, len2, chrs2, p2, len2o, matrix)
 }
 
-func editOpsFromCostMatrix(len1 int, chrs1 []rune, p1, o1 int, len2 int, chrs2 []rune, p2, o2 int, matrix []int) []levEditOp {
+func editOpsFromCostMatrix[T comparable](len1 int, chrs1 []T, p1, o1 int, len2 int, chrs2 []T, p2, o2 int, matrix []int) []levEditOp {
 	dir := 0
 	pos := matrix[len1*len2-1]
 	ops := make([]levEditOp, pos)
@@ -174,7 +174,7 @@ func editOpsToOpCodes(ops []levEditOp, len1, len2 int) []levOpCode {
 	var editType levEditType
 
 	for i := n; i > 0; {
-		for ops[opIdx].editType == levEditKeep && i > 0 {
+		for i > 0 && ops[opIdx].editType == levEditKeep {
 			i--
 			opIdx++
 		}
@@ -244,6 +244,7 @@ func editOpsToOpCodes(ops []levEditOp, len1, len2 int) []levOpCode {
 			oc.dend = ops[opIdx].dpos
 			spos = oc.send
 			dpos = oc.dend
+			opCodes[codeIdx] = oc
 
 			codeIdx++
 			oc2 := levOpCode{sbeg: spos, dbeg: dpos}
@@ -296,7 +297,7 @@ func shouldContinue(i int, editOps []levEditOp, opIdx int, editType levEditType,
 		editOps[opIdx].dpos == dpos && editOps[opIdx].spos == spos
 }
 
-func getMatchingBlocks(chrs1, chrs2 []rune) []levMatchingBlock {
+func getMatchingBlocks[T comparable](chrs1, chrs2 []T) []levMatchingBlock {
 	len1, len2 := len(chrs1), len(chrs2)
 
 	return getMatchingBlocksHelper(len1, len2, findEditOpsHelper(chrs1, len1, chrs2, len2))
@@ -474,6 +475,17 @@ func EditDistance(s1, s2 string) int {
 	return LevEditDistance(s1, s2, 0)
 }
 
+// EditDistanceUnit computes the Levenshtein distance between two strings
+// like EditDistance, with unit selecting whether runes or grapheme
+// clusters are counted as single edits.
+func EditDistanceUnit(s1, s2 string, unit ComparisonUnit) int {
+	if unit == GraphemeUnit {
+		chrs1, chrs2 := graphemeRunes(s1, s2)
+		return optimizedEditDistance(chrs1, chrs2, 0)
+	}
+	return EditDistance(s1, s2)
+}
+
 // LevEditDistance computes Levenshtein distance between 2 strings.
 // If xcost parameter is zero, the replace operation has weight 1.
 // Otherwise, all edit operations have equal weights of 1.
@@ -482,7 +494,53 @@ func LevEditDistance(s1, s2 string, xcost int) int {
 	return optimizedEditDistance(chrs1, chrs2, xcost)
 }
 
-func optimizedEditDistance(chrs1, chrs2 []rune, xcost int) int {
+// EditDistanceSlice computes the Levenshtein distance between two
+// sequences of any comparable elements, such as tokens or lines, like
+// EditDistance does for the runes of strings.
+func EditDistanceSlice[T comparable](s1, s2 []T) int {
+	return optimizedEditDistance(s1, s2, 0)
+}
+
+// RatioSlice computes a score of how close two sequences of any
+// comparable elements are, like Ratio does for the runes of strings.
+// Returns an integer score [0,100].
+func RatioSlice[T comparable](s1, s2 []T) int {
+	return int(round(100 * floatRatio(s1, s2)))
+}
+
+// Opcode describes how to turn s1[SrcStart:SrcEnd] into
+// s2[DestStart:DestEnd]: both are equal, or the first is replaced
+// with, deleted from or the second inserted into the other.
+type Opcode struct {
+	Kind               DiffKind
+	SrcStart, SrcEnd   int
+	DestStart, DestEnd int
+}
+
+// OpcodesSlice returns the operations turning s1 into s2, as in
+// python-Levenshtein's opcodes: the opcodes cover both sequences,
+// in order, with the fewest edits.
+func OpcodesSlice[T comparable](s1, s2 []T) []Opcode {
+	len1, len2 := len(s1), len(s2)
+	opCodes := editOpsToOpCodes(findEditOpsHelper(s1, len1, s2, len2), len1, len2)
+	opcodes := make([]Opcode, len(opCodes))
+	for i, oc := range opCodes {
+		opcodes[i] = Opcode{SrcStart: oc.sbeg, SrcEnd: oc.send, DestStart: oc.dbeg, DestEnd: oc.dend}
+		switch oc.editType {
+		case levEditKeep:
+			opcodes[i].Kind = DiffEqual
+		case levEditReplace:
+			opcodes[i].Kind = DiffReplace
+		case levEditInsert:
+			opcodes[i].Kind = DiffInsert
+		case levEditDelete:
+			opcodes[i].Kind = DiffDelete
+		}
+	}
+	return opcodes
+}
+
+func optimizedEditDistance[T comparable](chrs1, chrs2 []T, xcost int) int {
 	maxIdx := min(len(chrs1), len(chrs2))
 	for i := 0; i < maxIdx; i++ {
 		if chrs1[i] != chrs2[i] {
@@ -493,7 +551,7 @@ func optimizedEditDistance(chrs1, chrs2 []rune, xcost int) int {
 	return editDistance(chrs1[maxIdx:], chrs2[maxIdx:], xcost)
 }
 
-func editDistance(chrs1, chrs2 []rune, xcost int) int {
+func editDistance[T comparable](chrs1, chrs2 []T, xcost int) int {
 	len1 := len(chrs1)
 	len2 := len(chrs2)
 
@@ -1,6 +1,9 @@
 package fuzzy
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 var levEditDistanceTestData = [][]interface{}{
 	{"one", "", 3},
@@ -22,3 +25,94 @@ func TestLevEditDistance(t *testing.T) {
 		}
 	}
 }
+
+func TestGraphemeEditDistance(t *testing.T) {
+	s1, s2 := "\U0001F1FA\U0001F1F8", "\U0001F1EB\U0001F1F7"
+	if d := EditDistance(s1, s2); d != 2 {
+		t.Errorf("Edit distance from %+q to %+q is 2; got %d.", s1, s2, d)
+	}
+	if d := EditDistanceUnit(s1, s2, GraphemeUnit); d != 1 {
+		t.Errorf("Grapheme edit distance from %+q to %+q is 1; got %d.", s1, s2, d)
+	}
+	if d := EditDistanceUnit(s1, s2, RuneUnit); d != 2 {
+		t.Errorf("Rune edit distance from %+q to %+q is 2; got %d.", s1, s2, d)
+	}
+
+	// EditDistance keeps its type
+	var distance func(string, string) int = EditDistance
+	if d := distance("bart", "bort"); d != 1 {
+		t.Errorf("Edit distance from bart to bort is 1; got %d.", d)
+	}
+}
+
+func TestEditDistanceSlice(t *testing.T) {
+	for _, test := range levEditDistanceTestData {
+		s1, s2 := test[0].(string), test[1].(string)
+		if actual := EditDistanceSlice([]rune(s1), []rune(s2)); actual != test[2] {
+			t.Errorf("Edit distance from %v to %v is %d; got %d.", s1, s2, test[2], actual)
+		}
+	}
+
+	tokens1 := []string{"the", "new", "york", "mets"}
+	tokens2 := []string{"new", "york", "yankees"}
+	if d := EditDistanceSlice(tokens1, tokens2); d != 2 {
+		t.Errorf("Edit distance from %v to %v is 2; got %d.", tokens1, tokens2, d)
+	}
+	bases1, bases2 := []byte("GATTACA"), []byte("GCATGCU")
+	if d := EditDistanceSlice(bases1, bases2); d != 4 {
+		t.Errorf("Edit distance from %s to %s is 4; got %d.", bases1, bases2, d)
+	}
+}
+
+func TestRatioSlice(t *testing.T) {
+	s1, s2 := "fuzzy wuzzy was a bear", "wuzzy fuzzy was a bear"
+	assertRatio(t, "RatioSlice", s1, s2, Ratio(s1, s2), RatioSlice([]rune(s1), []rune(s2)))
+
+	lines1 := []string{"package main", "", "func main() {", "}"}
+	lines2 := []string{"package main", "", "import \"fmt\"", "", "func main() {", "}"}
+	assertRatio(t, "RatioSlice", "lines1", "lines2", 80, RatioSlice(lines1, lines2))
+	assertRatio(t, "RatioSlice", "[]", "[]", 0, RatioSlice([]int{}, []int{}))
+}
+
+func TestEditOpsToOpCodes(t *testing.T) {
+	expected := []levOpCode{
+		{levEditKeep, 0, 1, 0, 1},
+		{levEditReplace, 1, 2, 1, 2},
+		{levEditKeep, 2, 3, 2, 3},
+	}
+	// the leading keep block is stored
+	ops := []levEditOp{{levEditReplace, 1, 1}}
+	if actual := editOpsToOpCodes(ops, 3, 3); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected opcodes %v, got %v.", expected, actual)
+	}
+	// trailing keep operations do not index past the end of ops
+	ops = []levEditOp{{levEditKeep, 0, 0}, {levEditReplace, 1, 1}, {levEditKeep, 2, 2}}
+	if actual := editOpsToOpCodes(ops, 3, 3); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected opcodes %v, got %v.", expected, actual)
+	}
+}
+
+func TestOpcodesSlice(t *testing.T) {
+	kinds := []int{1, 2, 3, 4, 5}
+	expected := []Opcode{
+		{DiffEqual, 0, 1, 0, 1},
+		{DiffReplace, 1, 2, 1, 2},
+		{DiffEqual, 2, 4, 2, 4},
+		{DiffDelete, 4, 5, 4, 4},
+	}
+	if actual := OpcodesSlice(kinds, []int{1, 9, 3, 4}); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected opcodes %v, got %v.", expected, actual)
+	}
+
+	expected = []Opcode{{DiffInsert, 0, 0, 0, 2}}
+	if actual := OpcodesSlice([]string{}, []string{"a", "b"}); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected opcodes %v, got %v.", expected, actual)
+	}
+	expected = []Opcode{{DiffEqual, 0, 5, 0, 5}}
+	if actual := OpcodesSlice(kinds, kinds); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected opcodes %v, got %v.", expected, actual)
+	}
+	if actual := OpcodesSlice([]int{}, []int{}); len(actual) != 0 {
+		t.Errorf("Expected no opcodes, got %v.", actual)
+	}
+}
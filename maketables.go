@@ -0,0 +1,143 @@
+//go:build ignore
+// +build ignore
+
+// This program generates normtables.go, the Unicode normalization data
+// used by Normalize. Run it with
+//
+//	go run maketables.go
+//
+// The tables are derived from golang.org/x/text/unicode/norm, so that
+// fuzzy itself does not depend on packages outside the standard library.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	hangulBase = 0xAC00
+	hangulLast = 0xD7A3
+)
+
+func main() {
+	canonical := map[rune]string{}
+	compat := map[rune]string{}
+	classes := map[rune]uint8{}
+	compositions := map[[2]rune]rune{}
+
+	for r := rune(0); r <= unicode.MaxRune; r++ {
+		if !utf8.ValidRune(r) || (r >= hangulBase && r <= hangulLast) {
+			// Hangul syllables are decomposed algorithmically
+			continue
+		}
+		s := string(r)
+		nfd := norm.NFD.String(s)
+		nfkd := norm.NFKD.String(s)
+		if nfd != s {
+			canonical[r] = nfd
+		}
+		if nfkd != nfd {
+			compat[r] = nfkd
+		}
+		if ccc := norm.NFD.PropertiesString(s).CCC(); ccc != 0 {
+			classes[r] = ccc
+		}
+
+		// primary composites recompose from their decomposition
+		// minus its final rune, followed by that rune
+		decomposed := []rune(nfd)
+		if nfd == s || norm.NFC.String(s) != s || len(decomposed) < 2 {
+			continue
+		}
+		last := decomposed[len(decomposed)-1]
+		first := []rune(norm.NFC.String(string(decomposed[:len(decomposed)-1])))
+		if len(first) == 1 && norm.NFC.String(string(first[0])+string(last)) == s {
+			compositions[[2]rune{first[0], last}] = r
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "// Code generated by maketables.go; DO NOT EDIT.\n\n")
+	fmt.Fprintf(buf, "package fuzzy\n\n")
+	fmt.Fprintf(buf, "// normUnicodeVersion is the Unicode version of the normalization tables.\n")
+	fmt.Fprintf(buf, "const normUnicodeVersion = %q\n\n", norm.Version)
+
+	fmt.Fprintf(buf, "// canonicalDecompositions maps runes to their full canonical decomposition.\n")
+	fmt.Fprintf(buf, "var canonicalDecompositions = map[rune]string{")
+	writeStringTable(buf, canonical)
+	fmt.Fprintf(buf, "}\n\n")
+
+	fmt.Fprintf(buf, "// compatDecompositions maps runes to their full compatibility decomposition\n")
+	fmt.Fprintf(buf, "// where it differs from the canonical decomposition.\n")
+	fmt.Fprintf(buf, "var compatDecompositions = map[rune]string{")
+	writeStringTable(buf, compat)
+	fmt.Fprintf(buf, "}\n\n")
+
+	fmt.Fprintf(buf, "// combiningClasses maps runes to their non-zero canonical combining class.\n")
+	fmt.Fprintf(buf, "var combiningClasses = map[rune]uint8{")
+	keys := make([]rune, 0, len(classes))
+	for r := range classes {
+		keys = append(keys, r)
+	}
+	sortRunes(keys)
+	for i, r := range keys {
+		if i%6 == 0 {
+			fmt.Fprintf(buf, "\n")
+		}
+		fmt.Fprintf(buf, "0x%04X: %d, ", r, classes[r])
+	}
+	fmt.Fprintf(buf, "\n}\n\n")
+
+	fmt.Fprintf(buf, "// compositions maps pairs of runes to their primary composite.\n")
+	fmt.Fprintf(buf, "var compositions = map[[2]rune]rune{")
+	pairs := make([][2]rune, 0, len(compositions))
+	for pair := range compositions {
+		pairs = append(pairs, pair)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return compositions[pairs[i]] < compositions[pairs[j]]
+	})
+	for i, pair := range pairs {
+		if i%3 == 0 {
+			fmt.Fprintf(buf, "\n")
+		}
+		fmt.Fprintf(buf, "{0x%04X, 0x%04X}: 0x%04X, ", pair[0], pair[1], compositions[pair])
+	}
+	fmt.Fprintf(buf, "\n}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile("normtables.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func writeStringTable(buf *bytes.Buffer, table map[rune]string) {
+	keys := make([]rune, 0, len(table))
+	for r := range table {
+		keys = append(keys, r)
+	}
+	sortRunes(keys)
+	for i, r := range keys {
+		if i%4 == 0 {
+			fmt.Fprintf(buf, "\n")
+		}
+		fmt.Fprintf(buf, "0x%04X: %+q, ", r, table[r])
+	}
+	fmt.Fprintf(buf, "\n")
+}
+
+func sortRunes(runes []rune) {
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+}
@@ -0,0 +1,280 @@
+package fuzzy
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"math/bits"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// mersennePrime is 2^61-1, the modulus of the universal hash family
+// used to simulate random permutations of token hashes.
+const mersennePrime = (1 << 61) - 1
+
+// MinHasher computes MinHash signatures over the token sets of strings.
+// The fraction of positions at which two signatures agree is an unbiased
+// estimate of the Jaccard similarity of the underlying token sets.
+type MinHasher struct {
+	a []uint64
+	b []uint64
+}
+
+// NewMinHasher creates a MinHasher producing signatures of length numHashes.
+// Signatures are only comparable when produced by MinHashers created with
+// the same numHashes and seed. It panics if numHashes is negative.
+func NewMinHasher(numHashes int, seed int64) *MinHasher {
+	rng := rand.New(rand.NewSource(seed))
+	m := &MinHasher{a: make([]uint64, numHashes), b: make([]uint64, numHashes)}
+	for i := 0; i < numHashes; i++ {
+		m.a[i] = uint64(rng.Int63n(mersennePrime-1)) + 1
+		m.b[i] = uint64(rng.Int63n(mersennePrime))
+	}
+	return m
+}
+
+// Signature computes the MinHash signature of the set of
+// whitespace-separated tokens in s.
+func (m *MinHasher) Signature(s string) []uint64 {
+	return m.SetSignature(NewStringSet(strings.Fields(s)))
+}
+
+// SetSignature computes the MinHash signature of a set of tokens.
+// The signature of an empty set consists entirely of math.MaxUint64.
+func (m *MinHasher) SetSignature(set *StringSet) []uint64 {
+	sig := make([]uint64, len(m.a))
+	for i := range sig {
+		sig[i] = math.MaxUint64
+	}
+	for token := range set.elements {
+		x := hashToken(token)
+		for i := range sig {
+			if h := m.permute(i, x); h < sig[i] {
+				sig[i] = h
+			}
+		}
+	}
+	return sig
+}
+
+func (m *MinHasher) permute(i int, x uint64) uint64 {
+	hi, lo := bits.Mul64(m.a[i], x)
+	// fold the 128 bit product modulo 2^61-1
+	r := (lo & mersennePrime) + (lo >> 61) + (hi << 3)
+	r = (r & mersennePrime) + (r >> 61)
+	r += m.b[i]
+	r = (r & mersennePrime) + (r >> 61)
+	if r >= mersennePrime {
+		r -= mersennePrime
+	}
+	return r
+}
+
+func hashToken(token string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(token))
+	return h.Sum64() & mersennePrime
+}
+
+// SignatureSimilarity estimates the Jaccard similarity of two token sets
+// from their MinHash signatures. Returns a value in [0,1].
+func SignatureSimilarity(sig1, sig2 []uint64) float64 {
+	n := min(len(sig1), len(sig2))
+	if n == 0 {
+		return 0.0
+	}
+	agree := 0
+	for i := 0; i < n; i++ {
+		if sig1[i] == sig2[i] {
+			agree++
+		}
+	}
+	return float64(agree) / float64(n)
+}
+
+// LSHIndex buckets MinHash signatures by bands so that signatures
+// of similar token sets are likely to share at least one bucket.
+// Two sets with Jaccard similarity s become candidates with probability
+// 1-(1-s^rows)^bands.
+type LSHIndex struct {
+	// MaxBucketSize, when positive, makes CandidatePairs skip buckets
+	// holding more ids, such as those filled by a token shared by most
+	// strings, which would otherwise yield a number of pairs quadratic
+	// in their size.
+	MaxBucketSize int
+
+	bands   int
+	rows    int
+	buckets []map[uint64][]int
+	keys    map[int][]uint64
+}
+
+// NewLSHIndex creates an index that splits each signature into the given
+// number of bands of rows hashes each. Signatures added to the index must
+// be at least bands*rows long.
+func NewLSHIndex(bands, rows int) *LSHIndex {
+	idx := &LSHIndex{bands: bands, rows: rows, buckets: make([]map[uint64][]int, bands), keys: make(map[int][]uint64)}
+	for i := range idx.buckets {
+		idx.buckets[i] = make(map[uint64][]int)
+	}
+	return idx
+}
+
+// NewLSHIndexForThreshold creates an index for signatures of length numHashes
+// whose banding best approximates the given target Jaccard similarity.
+func NewLSHIndexForThreshold(numHashes int, threshold float64) *LSHIndex {
+	bands, rows := LSHParams(numHashes, threshold)
+	return NewLSHIndex(bands, rows)
+}
+
+// LSHParams chooses the number of bands and rows per band for signatures
+// of length numHashes so that the similarity at which the candidate
+// probability curve is steepest, (1/bands)^(1/rows), is closest to threshold.
+func LSHParams(numHashes int, threshold float64) (bands, rows int) {
+	bands, rows = numHashes, 1
+	bestErr := math.Inf(1)
+	for r := 1; r <= numHashes; r++ {
+		b := numHashes / r
+		knee := math.Pow(1/float64(b), 1/float64(r))
+		if err := math.Abs(knee - threshold); err < bestErr {
+			bestErr = err
+			bands, rows = b, r
+		}
+	}
+	return bands, rows
+}
+
+// Add indexes the signature under the given id.
+func (idx *LSHIndex) Add(id int, sig []uint64) {
+	keys := make([]uint64, idx.bands)
+	for band := range keys {
+		keys[band] = idx.bandKey(sig, band)
+		idx.buckets[band][keys[band]] = append(idx.buckets[band][keys[band]], id)
+	}
+	idx.keys[id] = keys
+}
+
+// Query returns the ids of all indexed signatures sharing
+// at least one band with sig.
+func (idx *LSHIndex) Query(sig []uint64) []int {
+	seen := make(map[int]bool)
+	ids := []int{}
+	for band := 0; band < idx.bands; band++ {
+		for _, id := range idx.buckets[band][idx.bandKey(sig, band)] {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// CandidatePairs returns every pair of ids sharing at least one band,
+// with the smaller id first. Each pair is reported once, from the first
+// band it shares. Bands whose bucket exceeds MaxBucketSize are ignored.
+func (idx *LSHIndex) CandidatePairs() [][2]int {
+	pairs := [][2]int{}
+	for band, buckets := range idx.buckets {
+		for _, ids := range buckets {
+			if idx.oversized(ids) {
+				continue
+			}
+			for i := 0; i < len(ids); i++ {
+				for j := i + 1; j < len(ids); j++ {
+					if idx.sharedBefore(ids[i], ids[j], band) {
+						continue
+					}
+					pair := [2]int{ids[i], ids[j]}
+					if pair[0] > pair[1] {
+						pair[0], pair[1] = pair[1], pair[0]
+					}
+					pairs = append(pairs, pair)
+				}
+			}
+		}
+	}
+	return pairs
+}
+
+// sharedBefore reports whether ids i and j share a bucket within the
+// size limit in a band before the given one.
+func (idx *LSHIndex) sharedBefore(i, j, band int) bool {
+	ki, kj := idx.keys[i], idx.keys[j]
+	for b := 0; b < band; b++ {
+		if ki[b] == kj[b] && !idx.oversized(idx.buckets[b][ki[b]]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (idx *LSHIndex) oversized(ids []int) bool {
+	return idx.MaxBucketSize > 0 && len(ids) > idx.MaxBucketSize
+}
+
+func (idx *LSHIndex) bandKey(sig []uint64, band int) uint64 {
+	h := fnv.New64a()
+	buf := make([]byte, 8)
+	for _, v := range sig[band*idx.rows : (band+1)*idx.rows] {
+		binary.LittleEndian.PutUint64(buf, v)
+		h.Write(buf)
+	}
+	return h.Sum64()
+}
+
+// DuplicatePair identifies two near-duplicate strings by their
+// indices in the input slice, along with their TokenSetRatio.
+type DuplicatePair struct {
+	I     int
+	J     int
+	Score int
+}
+
+// The MinHasher parameters used by NearDuplicates.
+const (
+	DefaultNumHashes   = 128
+	DefaultMinHashSeed = 1
+)
+
+// NearDuplicates finds pairs of near-duplicate strings without comparing
+// every pair. Strings are cleansed and indexed by the MinHash signatures
+// of their token sets, candidate pairs above roughly the target Jaccard
+// similarity threshold are taken from the LSH index, and each candidate
+// is verified with TokenSetRatio. Pairs scoring at least scoreCutoff
+// are returned. Signatures have DefaultNumHashes hashes seeded with
+// DefaultMinHashSeed; use NearDuplicatesWith to choose them.
+func NearDuplicates(strs []string, threshold float64, scoreCutoff int) []DuplicatePair {
+	return NearDuplicatesWith(strs, threshold, scoreCutoff, NewMinHasher(DefaultNumHashes, DefaultMinHashSeed))
+}
+
+// NearDuplicatesWith is like NearDuplicates, with signatures computed by
+// hasher. Longer signatures miss fewer near-duplicates but take more time
+// and memory per string, as the index holds more bands of each.
+func NearDuplicatesWith(strs []string, threshold float64, scoreCutoff int, hasher *MinHasher) []DuplicatePair {
+	idx := NewLSHIndexForThreshold(len(hasher.a), threshold)
+	cleansed := make([]string, len(strs))
+	for i, s := range strs {
+		cleansed[i] = Cleanse(s, false)
+		if strings.TrimSpace(cleansed[i]) != "" {
+			idx.Add(i, hasher.Signature(cleansed[i]))
+		}
+	}
+
+	dupes := []DuplicatePair{}
+	for _, pair := range idx.CandidatePairs() {
+		score := TokenSetRatio(cleansed[pair[0]], cleansed[pair[1]])
+		if score >= scoreCutoff {
+			dupes = append(dupes, DuplicatePair{I: pair[0], J: pair[1], Score: score})
+		}
+	}
+	sort.Slice(dupes, func(a, b int) bool {
+		if dupes[a].I != dupes[b].I {
+			return dupes[a].I < dupes[b].I
+		}
+		return dupes[a].J < dupes[b].J
+	})
+	return dupes
+}
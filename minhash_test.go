@@ -0,0 +1,99 @@
+package fuzzy
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSignatureSimilarity(t *testing.T) {
+	hasher := NewMinHasher(256, 42)
+
+	sig1 := hasher.Signature("new york mets vs atlanta braves")
+	sig2 := hasher.Signature("atlanta braves vs new york mets")
+	if sim := SignatureSimilarity(sig1, sig2); sim != 1 {
+		t.Errorf("Expected identical token sets to have similarity 1. Got %v", sim)
+	}
+
+	// true Jaccard similarity is 4/6
+	sig3 := hasher.Signature("a b c d e")
+	sig4 := hasher.Signature("a b c d f")
+	if sim := SignatureSimilarity(sig3, sig4); math.Abs(sim-4.0/6.0) > 0.1 {
+		t.Errorf("Expected estimated similarity to be close to %v. Got %v", 4.0/6.0, sim)
+	}
+
+	sig5 := hasher.Signature("alpha beta gamma")
+	if sim := SignatureSimilarity(sig1, sig5); sim > 0.1 {
+		t.Errorf("Expected disjoint token sets to have similarity close to 0. Got %v", sim)
+	}
+}
+
+func TestLSHParams(t *testing.T) {
+	bands, rows := LSHParams(128, 0.8)
+	if bands*rows > 128 {
+		t.Errorf("Expected bands*rows to fit in 128 hashes. Got %v*%v", bands, rows)
+	}
+	knee := math.Pow(1/float64(bands), 1/float64(rows))
+	if math.Abs(knee-0.8) > 0.05 {
+		t.Errorf("Expected threshold of %v bands and %v rows to be close to 0.8. Got %v", bands, rows, knee)
+	}
+}
+
+func TestLSHIndex(t *testing.T) {
+	hasher := NewMinHasher(100, 7)
+	idx := NewLSHIndexForThreshold(100, 0.5)
+	idx.Add(0, hasher.Signature("taylor swift the eras tour"))
+	idx.Add(1, hasher.Signature("the eras tour taylor swift"))
+	idx.Add(2, hasher.Signature("wayne hancock live"))
+
+	pairs := idx.CandidatePairs()
+	if len(pairs) != 1 || pairs[0] != [2]int{0, 1} {
+		t.Errorf("Expected a single candidate pair [0 1]. Got %v", pairs)
+	}
+
+	ids := idx.Query(hasher.Signature("wayne hancock live"))
+	if len(ids) != 1 || ids[0] != 2 {
+		t.Errorf("Expected query to return [2]. Got %v", ids)
+	}
+}
+
+func TestLSHIndexCandidatePairs(t *testing.T) {
+	hasher := NewMinHasher(100, 7)
+	idx := NewLSHIndex(100, 1)
+	for i := 0; i < 4; i++ {
+		idx.Add(i, hasher.Signature("taylor swift the eras tour"))
+	}
+	idx.Add(4, hasher.Signature("wayne hancock live"))
+
+	pairs := idx.CandidatePairs()
+	if len(pairs) != 6 {
+		t.Errorf("Expected each of the 6 pairs of identical signatures once. Got %v", pairs)
+	}
+
+	idx.MaxBucketSize = 3
+	if pairs := idx.CandidatePairs(); len(pairs) != 0 {
+		t.Errorf("Expected buckets over MaxBucketSize to be skipped. Got %v", pairs)
+	}
+}
+
+func TestNearDuplicates(t *testing.T) {
+	listings := []string{
+		"New York Mets vs. Atlanta Braves",
+		"Kate Bush",
+		"Atlanta Braves vs New York Mets",
+		"",
+		"",
+		"Jonathan Richman",
+	}
+	dupes := NearDuplicates(listings, 0.7, 90)
+	if len(dupes) != 1 {
+		t.Fatalf("Expected a single near-duplicate pair. Got %v", dupes)
+	}
+	if dupes[0].I != 0 || dupes[0].J != 2 || dupes[0].Score != 100 {
+		t.Errorf("Expected {0 2 100}. Got %v", dupes[0])
+	}
+
+	dupes = NearDuplicatesWith(listings, 0.7, 90, NewMinHasher(32, 7))
+	if len(dupes) != 1 || dupes[0].I != 0 || dupes[0].J != 2 {
+		t.Errorf("Expected a single near-duplicate pair {0 2}. Got %v", dupes)
+	}
+}
@@ -0,0 +1,65 @@
+package fuzzy
+
+// MongeElkanRatio computes the Monge-Elkan similarity of two strings:
+// each token of s1 is paired with its best matching token of s2 under
+// Ratio, and the scores of these pairs are averaged. Unlike the token set
+// scorers, which only count identical tokens, misspelled tokens still
+// match, so "jonathon richmond" and "jonathan richman" score highly.
+// Tokens are cleansed and split on whitespace.
+// The score is asymmetric: it measures how well the tokens of s1 are
+// covered by s2, so extra tokens in s2 are not penalized. Use
+// SymmetricMongeElkanRatio where that matters.
+func MongeElkanRatio(s1, s2 string) int {
+	return int(round(mongeElkan(tokenizeCleansed(s1), tokenizeCleansed(s2), Ratio)))
+}
+
+// SymmetricMongeElkanRatio computes the mean of MongeElkanRatio in
+// both directions, so the order of arguments does not matter.
+func SymmetricMongeElkanRatio(s1, s2 string) int {
+	tokens1, tokens2 := tokenizeCleansed(s1), tokenizeCleansed(s2)
+	return int(round(symmetricMongeElkan(tokens1, tokens2, Ratio)))
+}
+
+// MongeElkanScorer returns a scorer similar to MongeElkanRatio that pairs
+// tokens using the given inner scorer, such as JaroWinklerRatio. If
+// symmetric is true, the scorer averages both directions like
+// SymmetricMongeElkanRatio. Tokens are cleansed whatever opts says; its
+// other options, such as a Tokenizer, Synonyms and Stopwords, apply as for
+// TokenSetRatio, and nil uses the defaults.
+func MongeElkanScorer(inner func(string, string) int, symmetric bool, opts *TokenOptions) func(string, string) int {
+	o := opts.cleansed()
+	return func(s1, s2 string) int {
+		tokens1, tokens2 := o.tokenize(s1), o.tokenize(s2)
+		if symmetric {
+			return int(round(symmetricMongeElkan(tokens1, tokens2, inner)))
+		}
+		return int(round(mongeElkan(tokens1, tokens2, inner)))
+	}
+}
+
+func tokenizeCleansed(s string) []string {
+	o := TokenOptions{Cleanse: true}
+	return o.tokenize(s)
+}
+
+// mongeElkan averages the best inner score of each token of tokens1.
+func mongeElkan(tokens1, tokens2 []string, inner func(string, string) int) float64 {
+	if len(tokens1) == 0 || len(tokens2) == 0 {
+		return 0
+	}
+	total := 0
+	for _, token1 := range tokens1 {
+		best := 0
+		for _, token2 := range tokens2 {
+			if score := inner(token1, token2); score > best {
+				best = score
+			}
+		}
+		total += best
+	}
+	return float64(total) / float64(len(tokens1))
+}
+
+func symmetricMongeElkan(tokens1, tokens2 []string, inner func(string, string) int) float64 {
+	return (mongeElkan(tokens1, tokens2, inner) + mongeElkan(tokens2, tokens1, inner)) / 2
+}
@@ -0,0 +1,39 @@
+package fuzzy
+
+import (
+	"testing"
+)
+
+func TestMongeElkanRatio(t *testing.T) {
+	s1, s2 := "jonathon richmond", "Jonathan Richman"
+	assertRatio(t, "MongeElkanRatio", s1, s2, 84, MongeElkanRatio(s1, s2))
+	assertRatio(t, "JaccardRatio", s1, s2, 0, JaccardRatio(s1, s2))
+
+	assertRatioIs100(t, "MongeElkanRatio", "richman jonathan", "Jonathan Richman", MongeElkanRatio("richman jonathan", "Jonathan Richman"))
+	assertRatioIs100(t, "MongeElkanRatio", "richman", "Jonathan Richman", MongeElkanRatio("richman", "Jonathan Richman"))
+	assertRatio(t, "MongeElkanRatio", "", "Jonathan Richman", 0, MongeElkanRatio("", "Jonathan Richman"))
+}
+
+func TestSymmetricMongeElkanRatio(t *testing.T) {
+	s1, s2 := "richman", "Jonathan Richman"
+	assertRatioIsNot100(t, "SymmetricMongeElkanRatio", s1, s2, SymmetricMongeElkanRatio(s1, s2))
+	assertRatio(t, "SymmetricMongeElkanRatio", s2, s1, SymmetricMongeElkanRatio(s1, s2), SymmetricMongeElkanRatio(s2, s1))
+}
+
+func TestMongeElkanScorer(t *testing.T) {
+	s1, s2 := "jonathon richmond", "jonathan richman"
+	jaroWinkler := MongeElkanScorer(JaroWinklerRatio, false, nil)
+	if jaroWinkler(s1, s2) <= MongeElkanRatio(s1, s2) {
+		t.Error("Expected Jaro-Winkler to favor the shared prefixes")
+	}
+
+	symmetric := MongeElkanScorer(Ratio, true, &TokenOptions{Stopwords: NewStopwords("the")})
+	assertRatio(t, "MongeElkanScorer", "The Beatles", "beatles", 100, symmetric("The Beatles", "beatles"))
+
+	choices := []string{"Jonathan Richman", "Jonathan Davis", "Wayne Richman"}
+	match, err := ExtractOne(s1, choices, MongeElkanScorer(JaroWinklerRatio, true, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertMatch(t, s1, "Jonathan Richman", match.Match)
+}
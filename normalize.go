@@ -0,0 +1,202 @@
+package fuzzy
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//go:generate go run maketables.go
+
+// NormalizationForm selects one of the Unicode normalization forms
+// defined by UAX #15.
+type NormalizationForm int
+
+const (
+	// NFC is canonical decomposition followed by canonical composition.
+	NFC NormalizationForm = iota
+	// NFD is canonical decomposition.
+	NFD
+	// NFKC is compatibility decomposition followed by canonical composition.
+	NFKC
+	// NFKD is compatibility decomposition.
+	NFKD
+)
+
+const (
+	hangulSBase  = 0xAC00
+	hangulLBase  = 0x1100
+	hangulVBase  = 0x1161
+	hangulTBase  = 0x11A7
+	hangulLCount = 19
+	hangulVCount = 21
+	hangulTCount = 28
+	hangulNCount = hangulVCount * hangulTCount
+	hangulSCount = hangulLCount * hangulNCount
+)
+
+// Normalize converts s to the given Unicode normalization form, so that
+// canonically equivalent strings, such as "é" written as a single rune
+// and "e" followed by a combining acute accent, become identical.
+// The compatibility forms additionally fold ligatures, full-width
+// characters and the like into their plain equivalents.
+func Normalize(s string, form NormalizationForm) string {
+	if isASCII(s) {
+		return s
+	}
+	runes := decompose(s, form == NFKC || form == NFKD)
+	if form == NFC || form == NFKC {
+		runes = compose(runes)
+	}
+	return string(runes)
+}
+
+// FoldCase applies Unicode full case folding to s, so that strings
+// differing only in case, such as "STRASSE" and "straße", become identical.
+// The result is not necessarily normalized.
+func FoldCase(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if f, ok := fullCaseFolds[r]; ok {
+			b.WriteString(f)
+		} else if r == 'ı' {
+			// dotless i has no case folding outside of Turkic languages
+			b.WriteRune(r)
+		} else {
+			b.WriteRune(unicode.ToLower(unicode.ToUpper(r)))
+		}
+	}
+	return b.String()
+}
+
+// NormalizingProcessor returns a processor that converts strings to
+// the given normalization form and, optionally, folds their case.
+// Strings with the same processed form are visually identical.
+func NormalizingProcessor(form NormalizationForm, foldCase bool) func(string) string {
+	return func(s string) string {
+		s = Normalize(s, form)
+		if foldCase {
+			s = Normalize(FoldCase(s), form)
+		}
+		return s
+	}
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+func decompose(s string, compat bool) []rune {
+	runes := make([]rune, 0, len(s))
+	for _, r := range s {
+		if r >= hangulSBase && r < hangulSBase+hangulSCount {
+			sIndex := r - hangulSBase
+			runes = append(runes, hangulLBase+sIndex/hangulNCount)
+			runes = append(runes, hangulVBase+(sIndex%hangulNCount)/hangulTCount)
+			if t := sIndex % hangulTCount; t != 0 {
+				runes = append(runes, hangulTBase+t)
+			}
+			continue
+		}
+		if compat {
+			if d, ok := compatDecompositions[r]; ok {
+				runes = append(runes, []rune(d)...)
+				continue
+			}
+		}
+		if d, ok := canonicalDecompositions[r]; ok {
+			runes = append(runes, []rune(d)...)
+			continue
+		}
+		runes = append(runes, r)
+	}
+
+	// canonical ordering: stable sort each run of combining marks by class
+	for i := 1; i < len(runes); i++ {
+		ccc := combiningClasses[runes[i]]
+		if ccc == 0 {
+			continue
+		}
+		for j := i; j > 0; j-- {
+			prev := combiningClasses[runes[j-1]]
+			if prev == 0 || prev <= ccc {
+				break
+			}
+			runes[j-1], runes[j] = runes[j], runes[j-1]
+		}
+	}
+	return runes
+}
+
+func compose(runes []rune) []rune {
+	result := make([]rune, 0, len(runes))
+	starter := -1
+	var lastClass uint8
+	for _, r := range runes {
+		ccc := combiningClasses[r]
+		if starter >= 0 {
+			adjacent := starter == len(result)-1
+			if adjacent || (lastClass != 0 && lastClass < ccc) {
+				if c, ok := composePair(result[starter], r); ok {
+					result[starter] = c
+					continue
+				}
+			}
+		}
+		if ccc == 0 {
+			starter = len(result)
+		}
+		lastClass = ccc
+		result = append(result, r)
+	}
+	return result
+}
+
+func composePair(a, b rune) (rune, bool) {
+	if a >= hangulLBase && a < hangulLBase+hangulLCount &&
+		b >= hangulVBase && b < hangulVBase+hangulVCount {
+		return hangulSBase + ((a-hangulLBase)*hangulVCount+(b-hangulVBase))*hangulTCount, true
+	}
+	if a >= hangulSBase && a < hangulSBase+hangulSCount && (a-hangulSBase)%hangulTCount == 0 &&
+		b > hangulTBase && b < hangulTBase+hangulTCount {
+		return a + (b - hangulTBase), true
+	}
+	c, ok := compositions[[2]rune{a, b}]
+	return c, ok
+}
+
+// fullCaseFolds holds the case foldings that expand to more than one rune.
+var fullCaseFolds = map[rune]string{
+	0x00DF: "ss", 0x0130: "i\u0307", 0x0149: "\u02bcn", 0x01F0: "j\u030c",
+	0x0390: "\u03b9\u0308\u0301", 0x03B0: "\u03c5\u0308\u0301", 0x0587: "\u0565\u0582", 0x1E96: "h\u0331",
+	0x1E97: "t\u0308", 0x1E98: "w\u030a", 0x1E99: "y\u030a", 0x1E9A: "a\u02be",
+	0x1E9E: "ss", 0x1F50: "\u03c5\u0313", 0x1F52: "\u03c5\u0313\u0300", 0x1F54: "\u03c5\u0313\u0301",
+	0x1F56: "\u03c5\u0313\u0342", 0x1F80: "\u1f00\u03b9", 0x1F81: "\u1f01\u03b9", 0x1F82: "\u1f02\u03b9",
+	0x1F83: "\u1f03\u03b9", 0x1F84: "\u1f04\u03b9", 0x1F85: "\u1f05\u03b9", 0x1F86: "\u1f06\u03b9",
+	0x1F87: "\u1f07\u03b9", 0x1F88: "\u1f00\u03b9", 0x1F89: "\u1f01\u03b9", 0x1F8A: "\u1f02\u03b9",
+	0x1F8B: "\u1f03\u03b9", 0x1F8C: "\u1f04\u03b9", 0x1F8D: "\u1f05\u03b9", 0x1F8E: "\u1f06\u03b9",
+	0x1F8F: "\u1f07\u03b9", 0x1F90: "\u1f20\u03b9", 0x1F91: "\u1f21\u03b9", 0x1F92: "\u1f22\u03b9",
+	0x1F93: "\u1f23\u03b9", 0x1F94: "\u1f24\u03b9", 0x1F95: "\u1f25\u03b9", 0x1F96: "\u1f26\u03b9",
+	0x1F97: "\u1f27\u03b9", 0x1F98: "\u1f20\u03b9", 0x1F99: "\u1f21\u03b9", 0x1F9A: "\u1f22\u03b9",
+	0x1F9B: "\u1f23\u03b9", 0x1F9C: "\u1f24\u03b9", 0x1F9D: "\u1f25\u03b9", 0x1F9E: "\u1f26\u03b9",
+	0x1F9F: "\u1f27\u03b9", 0x1FA0: "\u1f60\u03b9", 0x1FA1: "\u1f61\u03b9", 0x1FA2: "\u1f62\u03b9",
+	0x1FA3: "\u1f63\u03b9", 0x1FA4: "\u1f64\u03b9", 0x1FA5: "\u1f65\u03b9", 0x1FA6: "\u1f66\u03b9",
+	0x1FA7: "\u1f67\u03b9", 0x1FA8: "\u1f60\u03b9", 0x1FA9: "\u1f61\u03b9", 0x1FAA: "\u1f62\u03b9",
+	0x1FAB: "\u1f63\u03b9", 0x1FAC: "\u1f64\u03b9", 0x1FAD: "\u1f65\u03b9", 0x1FAE: "\u1f66\u03b9",
+	0x1FAF: "\u1f67\u03b9", 0x1FB2: "\u1f70\u03b9", 0x1FB3: "\u03b1\u03b9", 0x1FB4: "\u03ac\u03b9",
+	0x1FB6: "\u03b1\u0342", 0x1FB7: "\u03b1\u0342\u03b9", 0x1FBC: "\u03b1\u03b9", 0x1FC2: "\u1f74\u03b9",
+	0x1FC3: "\u03b7\u03b9", 0x1FC4: "\u03ae\u03b9", 0x1FC6: "\u03b7\u0342", 0x1FC7: "\u03b7\u0342\u03b9",
+	0x1FCC: "\u03b7\u03b9", 0x1FD2: "\u03b9\u0308\u0300", 0x1FD3: "\u03b9\u0308\u0301", 0x1FD6: "\u03b9\u0342",
+	0x1FD7: "\u03b9\u0308\u0342", 0x1FE2: "\u03c5\u0308\u0300", 0x1FE3: "\u03c5\u0308\u0301", 0x1FE4: "\u03c1\u0313",
+	0x1FE6: "\u03c5\u0342", 0x1FE7: "\u03c5\u0308\u0342", 0x1FF2: "\u1f7c\u03b9", 0x1FF3: "\u03c9\u03b9",
+	0x1FF4: "\u03ce\u03b9", 0x1FF6: "\u03c9\u0342", 0x1FF7: "\u03c9\u0342\u03b9", 0x1FFC: "\u03c9\u03b9",
+	0xFB00: "ff", 0xFB01: "fi", 0xFB02: "fl", 0xFB03: "ffi",
+	0xFB04: "ffl", 0xFB05: "st", 0xFB06: "st", 0xFB13: "\u0574\u0576",
+	0xFB14: "\u0574\u0565", 0xFB15: "\u0574\u056b", 0xFB16: "\u057e\u0576", 0xFB17: "\u0574\u056d",
+}
@@ -0,0 +1,59 @@
+package fuzzy
+
+import (
+	"testing"
+)
+
+var normalizeData = []struct {
+	input string
+	form  NormalizationForm
+	want  string
+}{
+	{"e\u0301te\u0301", NFC, "\u00e9t\u00e9"},
+	{"\u00e9t\u00e9", NFD, "e\u0301te\u0301"},
+	{"\u1e69", NFD, "s\u0323\u0307"},
+	{"s\u0307\u0323", NFC, "\u1e69"},
+	{"\u212b", NFC, "\u00c5"},
+	{"\ufb01ne", NFC, "\ufb01ne"},
+	{"\ufb01ne", NFKC, "fine"},
+	{"\uff21\uff22\uff23", NFKC, "ABC"},
+	{"\u2460", NFKD, "1"},
+	{"\u1100\u1161\u11a8", NFC, "\uac01"},
+	{"\uac01", NFD, "\u1100\u1161\u11a8"},
+	{"plain ascii", NFKC, "plain ascii"},
+}
+
+func TestNormalize(t *testing.T) {
+	for _, testCase := range normalizeData {
+		actual := Normalize(testCase.input, testCase.form)
+		if actual != testCase.want {
+			t.Errorf("Normalize %+q (form %v): Expected %+q, got %+q.",
+				testCase.input, testCase.form, testCase.want, actual)
+		}
+	}
+}
+
+func TestFoldCase(t *testing.T) {
+	foldCaseData := [][]string{
+		{"STRASSE", "strasse"},
+		{"straße", "strasse"},
+		{"ΣΊΣΥΦΟΣ", "σίσυφοσ"},
+		{"σίσυφος", "σίσυφοσ"},
+		{"ǅemal", "ǆemal"},
+	}
+	for _, testCase := range foldCaseData {
+		actual := FoldCase(testCase[0])
+		if actual != testCase[1] {
+			t.Errorf("FoldCase %v: Expected %v, got %v.", testCase[0], testCase[1], actual)
+		}
+	}
+}
+
+func TestNormalizingProcessor(t *testing.T) {
+	processor := NormalizingProcessor(NFC, true)
+	s1, s2 := "CAFÉ", "café"
+	if processor(s1) != processor(s2) {
+		t.Errorf("Expected %+q and %+q to be processed identically. Got %+q and %+q",
+			s1, s2, processor(s1), processor(s2))
+	}
+}
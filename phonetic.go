@@ -0,0 +1,78 @@
+package fuzzy
+
+import (
+	"strings"
+
+	"github.com/paul-mannino/go-fuzzywuzzy/phonetic"
+)
+
+// PhoneticRatio computes a score of how alike two strings sound. Each
+// word is replaced by its Double Metaphone code, and the sorted codes
+// are compared as in TokenSortRatio, so "Stephen Smith" and
+// "Steven Schmidt" score 100. Where a word has an alternate
+// pronunciation, the code matching a word of the other string is used.
+// The function has the form f(string, string) -> int, so it can be
+// passed as a scorer to Extract, ExtractOne and ExtractWithoutOrder.
+func PhoneticRatio(s1, s2 string) int {
+	return phoneticRatio(s1, s2, phonetic.DoubleMetaphoneEncoder)
+}
+
+// PhoneticScorer returns a scorer similar to PhoneticRatio that codes
+// words with the given encoder, such as phonetic.ColognePhoneticEncoder
+// for German names. Encoders producing several codes per word, such as
+// phonetic.DaitchMokotoffEncoder, are handled like Double Metaphone:
+//
+//	matches, err := Extract("Müller", choices, 5, PhoneticScorer(phonetic.ColognePhoneticEncoder))
+func PhoneticScorer(encoder phonetic.Encoder) func(string, string) int {
+	return func(s1, s2 string) int {
+		return phoneticRatio(s1, s2, encoder)
+	}
+}
+
+func phoneticRatio(s1, s2 string, encoder phonetic.Encoder) int {
+	codes1 := phoneticCodes(s1, encoder)
+	codes2 := phoneticCodes(s2, encoder)
+	if len(codes1) == 0 || len(codes2) == 0 {
+		return 0
+	}
+
+	sorted1 := tokenSort(chooseCodes(codes1, codes2))
+	sorted2 := tokenSort(chooseCodes(codes2, codes1))
+	return Ratio(sorted1, sorted2)
+}
+
+// phoneticCodes returns the codes of each word of s,
+// skipping words without any.
+func phoneticCodes(s string, encoder phonetic.Encoder) [][]string {
+	words := strings.Fields(Cleanse(s, false))
+	codes := make([][]string, 0, len(words))
+	for _, word := range words {
+		if wordCodes := phonetic.Codes(encoder, word); len(wordCodes) > 0 {
+			codes = append(codes, wordCodes)
+		}
+	}
+	return codes
+}
+
+// chooseCodes picks one code per word, preferring
+// a code that also occurs among the other codes.
+func chooseCodes(codes, other [][]string) []string {
+	available := make(map[string]bool)
+	for _, wordCodes := range other {
+		for _, code := range wordCodes {
+			available[code] = true
+		}
+	}
+
+	chosen := make([]string, len(codes))
+	for i, wordCodes := range codes {
+		chosen[i] = wordCodes[0]
+		for _, code := range wordCodes {
+			if available[code] {
+				chosen[i] = code
+				break
+			}
+		}
+	}
+	return chosen
+}
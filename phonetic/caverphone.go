@@ -0,0 +1,100 @@
+package phonetic
+
+import (
+	"regexp"
+	"strings"
+)
+
+// caverphoneLength is the length Caverphone codes are padded to.
+const caverphoneLength = 10
+
+// caverphoneRules are the rewrite rules of Caverphone 2.0, in the order
+// they are applied. Lower case letters are still to be coded, upper case
+// letters are final, "2" marks a removed letter and "3" a vowel.
+var caverphoneRules = compileRewriteRules(
+	"e$", "",
+	"^cough", "cou2f",
+	"^rough", "rou2f",
+	"^tough", "tou2f",
+	"^enough", "enou2f",
+	"^trough", "trou2f",
+	"^gn", "2n",
+	"mb$", "m2",
+	"cq", "2q",
+	"ci", "si",
+	"ce", "se",
+	"cy", "sy",
+	"tch", "2ch",
+	"c", "k",
+	"q", "k",
+	"x", "k",
+	"v", "f",
+	"dg", "2g",
+	"tio", "sio",
+	"tia", "sia",
+	"d", "t",
+	"ph", "fh",
+	"b", "p",
+	"sh", "s2",
+	"z", "s",
+	"^[aeiou]", "A",
+	"[aeiou]", "3",
+	"j", "y",
+	"^y3", "Y3",
+	"^y", "A",
+	"y", "3",
+	"3gh3", "3kh3",
+	"gh", "22",
+	"g", "k",
+	"s+", "S",
+	"t+", "T",
+	"p+", "P",
+	"k+", "K",
+	"f+", "F",
+	"m+", "M",
+	"n+", "N",
+	"w3", "W3",
+	"wh3", "Wh3",
+	"w$", "3",
+	"w", "2",
+	"^h", "A",
+	"h", "2",
+	"r3", "R3",
+	"r$", "3",
+	"r", "2",
+	"l3", "L3",
+	"l$", "3",
+	"l", "2",
+	"2", "",
+	"3$", "A",
+	"3", "",
+)
+
+type rewriteRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+func compileRewriteRules(rules ...string) []rewriteRule {
+	compiled := make([]rewriteRule, 0, len(rules)/2)
+	for i := 0; i < len(rules); i += 2 {
+		compiled = append(compiled, rewriteRule{regexp.MustCompile(rules[i]), rules[i+1]})
+	}
+	return compiled
+}
+
+// Caverphone computes the Caverphone 2.0 code of word, an algorithm
+// designed by David Hood to match names in New Zealand electoral rolls.
+// Codes are padded with "1" to ten characters: "Stevenson" codes as
+// "STFNSN1111". Returns an empty string if word has no letters.
+func Caverphone(word string) string {
+	code := strings.ToLower(string(upperLetters(word)))
+	if code == "" {
+		return ""
+	}
+	for _, rule := range caverphoneRules {
+		code = rule.pattern.ReplaceAllString(code, rule.replacement)
+	}
+	code += strings.Repeat("1", caverphoneLength)
+	return code[:caverphoneLength]
+}
@@ -0,0 +1,20 @@
+package phonetic
+
+import (
+	"testing"
+)
+
+var caverphoneData = [][]string{
+	{"Peter", "PTA1111111"},
+	{"Stevenson", "STFNSN1111"},
+	{"Tomlinson", "TMLNSN1111"},
+	{"Lee", "LA11111111"},
+	{"Mclaverty", "MKLFTA1111"},
+	{"Stephen", "STFN111111"},
+	{"Steven", "STFN111111"},
+	{"", ""},
+}
+
+func TestCaverphone(t *testing.T) {
+	assertEncodings(t, "Caverphone", Caverphone, caverphoneData)
+}
@@ -0,0 +1,86 @@
+package phonetic
+
+import "strings"
+
+// ColognePhonetic computes the Kölner Phonetik code of word, a phonetic
+// algorithm designed by Hans Joachim Postel for German names. Letters
+// are coded as digits, with "0" for vowels; repeated digits are coded
+// once and vowels are dropped except at the beginning, so "Müller" and
+// "Mueller" both code as "657", and "Meyer" and "Maier" as "67".
+// Returns an empty string if word has no letters.
+func ColognePhonetic(word string) string {
+	w := upperLetters(word)
+	at := func(i int) byte {
+		if i < 0 || i >= len(w) {
+			return 0
+		}
+		return w[i]
+	}
+	in := func(c byte, letters string) bool {
+		return c != 0 && strings.IndexByte(letters, c) >= 0
+	}
+
+	digits := make([]byte, 0, len(w)+1)
+	for i, c := range w {
+		prev, next := at(i-1), at(i+1)
+		var code string
+		switch c {
+		case 'A', 'E', 'I', 'J', 'O', 'U', 'Y':
+			code = "0"
+		case 'B':
+			code = "1"
+		case 'P':
+			if next == 'H' {
+				code = "3"
+			} else {
+				code = "1"
+			}
+		case 'D', 'T':
+			if in(next, "CSZ") {
+				code = "8"
+			} else {
+				code = "2"
+			}
+		case 'F', 'V', 'W':
+			code = "3"
+		case 'G', 'K', 'Q':
+			code = "4"
+		case 'C':
+			switch {
+			case i == 0 && in(next, "AHKLOQRUX"):
+				code = "4"
+			case i > 0 && in(next, "AHKOQUX") && !in(prev, "SZ"):
+				code = "4"
+			default:
+				code = "8"
+			}
+		case 'X':
+			if in(prev, "CKQ") {
+				code = "8"
+			} else {
+				code = "48"
+			}
+		case 'L':
+			code = "5"
+		case 'M', 'N':
+			code = "6"
+		case 'R':
+			code = "7"
+		case 'S', 'Z':
+			code = "8"
+		}
+		for j := 0; j < len(code); j++ {
+			if len(digits) == 0 || digits[len(digits)-1] != code[j] {
+				digits = append(digits, code[j])
+			}
+		}
+	}
+
+	result := make([]byte, 0, len(digits))
+	for i, d := range digits {
+		if d != '0' || i == 0 {
+			result = append(result, d)
+		}
+	}
+	return string(result)
+}
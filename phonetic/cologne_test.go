@@ -0,0 +1,25 @@
+package phonetic
+
+import (
+	"testing"
+)
+
+var colognePhoneticData = [][]string{
+	{"Müller-Lüdenscheidt", "65752682"},
+	{"Wikipedia", "3412"},
+	{"Müller", "657"},
+	{"Mueller", "657"},
+	{"Meyer", "67"},
+	{"Maier", "67"},
+	{"Schmidt", "862"},
+	{"Schmitt", "862"},
+	{"Christoph", "47823"},
+	{"Xaver", "4837"},
+	{"Bäcker", "147"},
+	{"Becker", "147"},
+	{"", ""},
+}
+
+func TestColognePhonetic(t *testing.T) {
+	assertEncodings(t, "ColognePhonetic", ColognePhonetic, colognePhoneticData)
+}
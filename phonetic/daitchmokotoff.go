@@ -0,0 +1,174 @@
+package phonetic
+
+import "strings"
+
+// daitchMokotoffLength is the length Daitch-Mokotoff codes are padded
+// and truncated to.
+const daitchMokotoffLength = 6
+
+// dmRule gives the codes of a group of letters at the start of a name,
+// before a vowel and in any other position. Alternative codes are
+// separated by "|", and an empty code leaves the letters uncoded.
+type dmRule struct {
+	patterns                  string
+	start, beforeVowel, other string
+}
+
+var daitchMokotoffRules = []dmRule{
+	{"AI AJ AY", "0", "1", ""},
+	{"AU", "0", "7", ""},
+	{"A", "0", "", ""},
+	{"B", "7", "7", "7"},
+	{"CHS", "5", "54", "54"},
+	{"CH", "5|4", "5|4", "5|4"},
+	{"CK", "5|45", "5|45", "5|45"},
+	{"CZ CS CSZ CZS", "4", "4", "4"},
+	{"C", "5|4", "5|4", "5|4"},
+	{"DRZ DRS", "4", "4", "4"},
+	{"DS DSH DSZ", "4", "4", "4"},
+	{"DZ DZH DZS", "4", "4", "4"},
+	{"D DT", "3", "3", "3"},
+	{"EI EJ EY", "0", "1", ""},
+	{"EU", "1", "1", ""},
+	{"E", "0", "", ""},
+	{"FB F", "7", "7", "7"},
+	{"G", "5", "5", "5"},
+	{"H", "5", "5", ""},
+	{"IA IE IO IU", "1", "", ""},
+	{"I", "0", "", ""},
+	{"J", "1|4", "|4", "|4"},
+	{"KS", "5", "54", "54"},
+	{"KH K", "5", "5", "5"},
+	{"L", "8", "8", "8"},
+	{"MN NM", "66", "66", "66"},
+	{"M N", "6", "6", "6"},
+	{"OI OJ OY", "0", "1", ""},
+	{"O", "0", "", ""},
+	{"P PF PH", "7", "7", "7"},
+	{"Q", "5", "5", "5"},
+	{"RZ RS", "94|4", "94|4", "94|4"},
+	{"R", "9", "9", "9"},
+	{"SCHTSCH SCHTSH SCHTCH", "2", "4", "4"},
+	{"SCH", "4", "4", "4"},
+	{"SHTCH SHCH SHTSH", "2", "4", "4"},
+	{"SHT SCHT SCHD", "2", "43", "43"},
+	{"SH", "4", "4", "4"},
+	{"STCH STSCH SC", "2", "4", "4"},
+	{"STRZ STRS STSH", "2", "4", "4"},
+	{"ST", "2", "43", "43"},
+	{"SZCZ SZCS", "2", "4", "4"},
+	{"SZT SHD SZD SD", "2", "43", "43"},
+	{"SZ S", "4", "4", "4"},
+	{"TCH TTCH TTSCH", "4", "4", "4"},
+	{"TH", "3", "3", "3"},
+	{"TRZ TRS", "4", "4", "4"},
+	{"TSCH TSH", "4", "4", "4"},
+	{"TS TTS TTSZ TC", "4", "4", "4"},
+	{"TZ TTZ TZS TSZ", "4", "4", "4"},
+	{"T", "3", "3", "3"},
+	{"UI UJ UY", "0", "1", ""},
+	{"U UE", "0", "", ""},
+	{"V W", "7", "7", "7"},
+	{"X", "5", "54", "54"},
+	{"Y", "1", "", ""},
+	{"ZDZ ZDZH ZHDZH", "2", "4", "4"},
+	{"ZD ZHD", "2", "43", "43"},
+	{"ZH ZS ZSCH ZSH", "4", "4", "4"},
+	{"Z", "4", "4", "4"},
+}
+
+// daitchMokotoffPatterns indexes the rules by letter group.
+var daitchMokotoffPatterns, daitchMokotoffMaxPattern = indexDaitchMokotoffRules()
+
+func indexDaitchMokotoffRules() (map[string]*dmRule, int) {
+	patterns := make(map[string]*dmRule)
+	maxPattern := 0
+	for i := range daitchMokotoffRules {
+		rule := &daitchMokotoffRules[i]
+		for _, pattern := range strings.Fields(rule.patterns) {
+			patterns[pattern] = rule
+			if len(pattern) > maxPattern {
+				maxPattern = len(pattern)
+			}
+		}
+	}
+	return patterns, maxPattern
+}
+
+// DaitchMokotoff computes the Daitch-Mokotoff Soundex codes of word, a
+// refinement of Soundex for Slavic and Yiddish surnames with six-digit
+// codes. Letter groups that can be pronounced in more than one way, such
+// as "ch" or "rz", produce a code for each pronunciation, so "Peters"
+// codes as both "739400" and "734000". The codes are returned in the
+// order the alternatives are listed in the rules.
+// Returns nil if word has no letters.
+func DaitchMokotoff(word string) []string {
+	w := string(upperLetters(word))
+	if w == "" {
+		return nil
+	}
+
+	type branch struct {
+		code []byte
+		last string
+	}
+	branches := []branch{{}}
+	for i := 0; i < len(w); {
+		var rule *dmRule
+		n := min(daitchMokotoffMaxPattern, len(w)-i)
+		for ; n > 0; n-- {
+			if rule = daitchMokotoffPatterns[w[i:i+n]]; rule != nil {
+				break
+			}
+		}
+
+		codes := rule.other
+		switch {
+		case i == 0:
+			codes = rule.start
+		case i+n < len(w) && isVowel(w[i+n]):
+			codes = rule.beforeVowel
+		}
+
+		next := make([]branch, 0, len(branches))
+		seen := make(map[string]bool)
+		for _, b := range branches {
+			for _, code := range strings.Split(codes, "|") {
+				nb := branch{code: b.code, last: code}
+				if code != "" && !strings.HasSuffix(b.last, code) {
+					nb.code = append(append([]byte{}, b.code...), code...)
+				}
+				if key := string(nb.code) + "/" + nb.last; !seen[key] {
+					seen[key] = true
+					next = append(next, nb)
+				}
+			}
+		}
+		branches = next
+		i += n
+	}
+
+	result := make([]string, 0, len(branches))
+	for _, b := range branches {
+		code := string(b.code) + strings.Repeat("0", daitchMokotoffLength)
+		if code = code[:daitchMokotoffLength]; !contains(result, code) {
+			result = append(result, code)
+		}
+	}
+	return result
+}
+
+type daitchMokotoffEncoder struct{}
+
+// Encode returns the first Daitch-Mokotoff code of word.
+func (daitchMokotoffEncoder) Encode(word string) string {
+	if codes := DaitchMokotoff(word); len(codes) > 0 {
+		return codes[0]
+	}
+	return ""
+}
+
+// EncodeAll returns all Daitch-Mokotoff codes of word.
+func (daitchMokotoffEncoder) EncodeAll(word string) []string {
+	return DaitchMokotoff(word)
+}
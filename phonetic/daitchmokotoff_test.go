@@ -0,0 +1,36 @@
+package phonetic
+
+import (
+	"reflect"
+	"testing"
+)
+
+var daitchMokotoffData = []struct {
+	word     string
+	expected []string
+}{
+	{"Auerbach", []string{"097500", "097400"}},
+	{"Ohrbach", []string{"097500", "097400"}},
+	{"Moskowitz", []string{"645740"}},
+	{"Peters", []string{"739400", "734000"}},
+	{"Jackson", []string{"154600", "145460", "454600", "445460"}},
+	{"Schwarzenegger", []string{"479465", "474659"}},
+	{"Kowalski", []string{"578450"}},
+	{"Kovalsky", []string{"578450"}},
+	{"Lipshitz", []string{"874400"}},
+	{"Szlachter", []string{"485390", "484390"}},
+	{"Schlachter", []string{"485390", "484390"}},
+	{"Müller", []string{"689000"}},
+	{"", nil},
+}
+
+func TestDaitchMokotoff(t *testing.T) {
+	for _, testCase := range daitchMokotoffData {
+		if actual := DaitchMokotoff(testCase.word); !reflect.DeepEqual(actual, testCase.expected) {
+			t.Errorf("DaitchMokotoff %v: Expected %v, got %v.", testCase.word, testCase.expected, actual)
+		}
+	}
+	if actual := DaitchMokotoffEncoder.Encode("Peters"); actual != "739400" {
+		t.Errorf("DaitchMokotoffEncoder.Encode Peters: Expected 739400, got %v.", actual)
+	}
+}
@@ -0,0 +1,573 @@
+package phonetic
+
+import "strings"
+
+// doubleMetaphoneLength is the length Double Metaphone codes are truncated to.
+const doubleMetaphoneLength = 4
+
+// DoubleMetaphone computes the Double Metaphone codes of word, as described
+// by Lawrence Philips. Double Metaphone improves on Metaphone with rules for
+// names of Germanic, Slavic, Romance, Greek and Chinese origin, and returns
+// an alternate code where a word has a second common pronunciation:
+// "Smith" codes as "SM0" and "XMT", and "Schmidt" as "XMT" and "SMT".
+// The alternate code equals the primary code for most words.
+// Both codes are at most four characters long.
+func DoubleMetaphone(word string) (primary, alternate string) {
+	e := newDoubleMetaphone(word)
+	e.encode()
+	return e.primary.String(), e.alternate.String()
+}
+
+type doubleMetaphoneEncoder struct{}
+
+// Encode returns the primary Double Metaphone code of word.
+func (doubleMetaphoneEncoder) Encode(word string) string {
+	primary, _ := DoubleMetaphone(word)
+	return primary
+}
+
+// EncodeAll returns the primary and alternate Double Metaphone codes of word.
+func (doubleMetaphoneEncoder) EncodeAll(word string) []string {
+	primary, alternate := DoubleMetaphone(word)
+	return []string{primary, alternate}
+}
+
+type doubleMetaphone struct {
+	w                  []rune
+	slavoGermanic      bool
+	primary, alternate strings.Builder
+}
+
+func newDoubleMetaphone(word string) *doubleMetaphone {
+	upper := strings.ToUpper(strings.TrimSpace(word))
+	return &doubleMetaphone{
+		w: []rune(upper),
+		slavoGermanic: strings.ContainsAny(upper, "WK") ||
+			strings.Contains(upper, "CZ") || strings.Contains(upper, "WITZ"),
+	}
+}
+
+// at returns the rune at i, or 0 if i is out of range.
+func (e *doubleMetaphone) at(i int) rune {
+	if i < 0 || i >= len(e.w) {
+		return 0
+	}
+	return e.w[i]
+}
+
+// matches reports whether any of the candidates, which all have
+// the same length, occurs at position i.
+func (e *doubleMetaphone) matches(i int, candidates ...string) bool {
+	for _, candidate := range candidates {
+		n := len(candidate)
+		if i >= 0 && i+n <= len(e.w) && string(e.w[i:i+n]) == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *doubleMetaphone) isVowel(i int) bool {
+	return strings.ContainsRune("AEIOUY", e.at(i)) && e.at(i) != 0
+}
+
+func (e *doubleMetaphone) isLast(i int) bool {
+	return i == len(e.w)-1
+}
+
+// add appends to both codes, or to the primary and the alternate code
+// separately if an alternate is given.
+func (e *doubleMetaphone) add(primary string, alternate ...string) {
+	e.addPrimary(primary)
+	if len(alternate) > 0 {
+		e.addAlternate(alternate[0])
+	} else {
+		e.addAlternate(primary)
+	}
+}
+
+func (e *doubleMetaphone) addPrimary(s string) {
+	if room := doubleMetaphoneLength - e.primary.Len(); room > 0 {
+		e.primary.WriteString(s[:min(room, len(s))])
+	}
+}
+
+func (e *doubleMetaphone) addAlternate(s string) {
+	if room := doubleMetaphoneLength - e.alternate.Len(); room > 0 {
+		e.alternate.WriteString(s[:min(room, len(s))])
+	}
+}
+
+func (e *doubleMetaphone) complete() bool {
+	return e.primary.Len() >= doubleMetaphoneLength && e.alternate.Len() >= doubleMetaphoneLength
+}
+
+// skip returns the index after the rune at i, also skipping the
+// following rune if it is one of the given letters.
+func (e *doubleMetaphone) skip(i int, letters string) int {
+	if e.at(i+1) != 0 && strings.ContainsRune(letters, e.at(i+1)) {
+		return i + 2
+	}
+	return i + 1
+}
+
+func (e *doubleMetaphone) encode() {
+	i := 0
+	if e.matches(0, "GN", "KN", "PN", "WR", "PS") {
+		i = 1
+	}
+	for !e.complete() && i < len(e.w) {
+		switch c := e.w[i]; c {
+		case 'A', 'E', 'I', 'O', 'U', 'Y':
+			if i == 0 {
+				e.add("A")
+			}
+			i++
+		case 'B':
+			e.add("P")
+			i = e.skip(i, "B")
+		case 'Ç':
+			e.add("S")
+			i++
+		case 'C':
+			i = e.encodeC(i)
+		case 'D':
+			i = e.encodeD(i)
+		case 'F', 'K', 'N', 'Q', 'V':
+			code := string(c)
+			switch c {
+			case 'Q':
+				code = "K"
+			case 'V':
+				code = "F"
+			}
+			e.add(code)
+			i = e.skip(i, string(c))
+		case 'G':
+			i = e.encodeG(i)
+		case 'H':
+			// kept only when first or between vowels
+			if (i == 0 || e.isVowel(i-1)) && e.isVowel(i+1) {
+				e.add("H")
+				i += 2
+			} else {
+				i++
+			}
+		case 'J':
+			i = e.encodeJ(i)
+		case 'L':
+			i = e.encodeL(i)
+		case 'M':
+			e.add("M")
+			if e.at(i+1) == 'M' || (e.matches(i-1, "UMB") && (i+1 == len(e.w)-1 || e.matches(i+2, "ER"))) {
+				// "dumb", "thumb"
+				i += 2
+			} else {
+				i++
+			}
+		case 'Ñ':
+			e.add("N")
+			i++
+		case 'P':
+			if e.at(i+1) == 'H' {
+				e.add("F")
+				i += 2
+			} else {
+				e.add("P")
+				i = e.skip(i, "PB")
+			}
+		case 'R':
+			if e.isLast(i) && !e.slavoGermanic && e.matches(i-2, "IE") && !e.matches(i-4, "ME", "MA") {
+				// French, as in "Rogier"
+				e.addAlternate("R")
+			} else {
+				e.add("R")
+			}
+			i = e.skip(i, "R")
+		case 'S':
+			i = e.encodeS(i)
+		case 'T':
+			i = e.encodeT(i)
+		case 'W':
+			i = e.encodeW(i)
+		case 'X':
+			i = e.encodeX(i)
+		case 'Z':
+			i = e.encodeZ(i)
+		default:
+			i++
+		}
+	}
+}
+
+func (e *doubleMetaphone) encodeC(i int) int {
+	switch {
+	case e.germanicCH(i):
+		// "ach" in Germanic names, as in "Bacher", and "chianti"
+		e.add("K")
+		return i + 2
+	case i == 0 && e.matches(i, "CAESAR"):
+		e.add("S")
+		return i + 2
+	case e.matches(i, "CH"):
+		return e.encodeCH(i)
+	case e.matches(i, "CZ") && !e.matches(i-2, "WICZ"):
+		// "Czerny"
+		e.add("S", "X")
+		return i + 2
+	case e.matches(i+1, "CIA"):
+		// "focaccia"
+		e.add("X")
+		return i + 3
+	case e.matches(i, "CC") && !(i == 1 && e.at(0) == 'M'):
+		// double "cc", but not "McClelland"
+		if e.matches(i+2, "I", "E", "H") && !e.matches(i+2, "HU") {
+			if (i == 1 && e.at(0) == 'A') || e.matches(i-1, "UCCEE", "UCCES") {
+				// "accident", "accede", "succeed"
+				e.add("KS")
+			} else {
+				// "bacci", "bertucci"
+				e.add("X")
+			}
+			return i + 3
+		}
+		e.add("K")
+		return i + 2
+	case e.matches(i, "CK", "CG", "CQ"):
+		e.add("K")
+		return i + 2
+	case e.matches(i, "CI", "CE", "CY"):
+		if e.matches(i, "CIO", "CIE", "CIA") {
+			// Italian, as in "ciao"
+			e.add("S", "X")
+		} else {
+			e.add("S")
+		}
+		return i + 2
+	}
+
+	e.add("K")
+	switch {
+	case e.matches(i+1, " C", " Q", " G"):
+		// "Mac Caffrey", "Mac Gregor"
+		return i + 3
+	case e.matches(i+1, "C", "K", "Q") && !e.matches(i+1, "CE", "CI"):
+		return i + 2
+	}
+	return i + 1
+}
+
+func (e *doubleMetaphone) germanicCH(i int) bool {
+	switch {
+	case e.matches(i, "CHIA"):
+		return true
+	case i <= 1, e.isVowel(i - 2), !e.matches(i-1, "ACH"):
+		return false
+	}
+	c := e.at(i + 2)
+	return (c != 'I' && c != 'E') || e.matches(i-2, "BACHER", "MACHER")
+}
+
+func (e *doubleMetaphone) encodeCH(i int) int {
+	switch {
+	case i > 0 && e.matches(i, "CHAE"):
+		// "Michael"
+		e.add("K", "X")
+	case i == 0 && (e.matches(i+1, "HARAC", "HARIS") || e.matches(i+1, "HOR", "HYM", "HIA", "HEM")) &&
+		!e.matches(0, "CHORE"):
+		// Greek roots, as in "chemistry" and "chorus"
+		e.add("K")
+	case e.matches(0, "VAN ", "VON ") || e.matches(0, "SCH") ||
+		e.matches(i-2, "ORCHES", "ARCHIT", "ORCHID") ||
+		e.matches(i+2, "T", "S") ||
+		((e.matches(i-1, "A", "O", "U", "E") || i == 0) &&
+			(e.matches(i+2, "L", "R", "N", "M", "B", "H", "F", "V", "W", " ") || i+1 == len(e.w)-1)):
+		// Germanic and Greek "ch" for a "kh" sound
+		e.add("K")
+	case i > 0:
+		if e.matches(0, "MC") {
+			e.add("K")
+		} else {
+			e.add("X", "K")
+		}
+	default:
+		e.add("X")
+	}
+	return i + 2
+}
+
+func (e *doubleMetaphone) encodeD(i int) int {
+	switch {
+	case e.matches(i, "DG"):
+		if e.matches(i+2, "I", "E", "Y") {
+			// "edge"
+			e.add("J")
+			return i + 3
+		}
+		// "Edgar"
+		e.add("TK")
+		return i + 2
+	case e.matches(i, "DT", "DD"):
+		e.add("T")
+		return i + 2
+	}
+	e.add("T")
+	return i + 1
+}
+
+func (e *doubleMetaphone) encodeG(i int) int {
+	switch {
+	case e.at(i+1) == 'H':
+		return e.encodeGH(i)
+	case e.at(i+1) == 'N':
+		switch {
+		case i == 1 && e.isVowel(0) && !e.slavoGermanic:
+			e.add("KN", "N")
+		case !e.matches(i+2, "EY") && e.at(i+1) != 'Y' && !e.slavoGermanic:
+			e.add("N", "KN")
+		default:
+			e.add("KN")
+		}
+		return i + 2
+	case e.matches(i+1, "LI") && !e.slavoGermanic:
+		// "tagliaro"
+		e.add("KL", "L")
+		return i + 2
+	case i == 0 && (e.at(i+1) == 'Y' ||
+		e.matches(i+1, "ES", "EP", "EB", "EL", "EY", "IB", "IL", "IN", "IE", "EI", "ER")):
+		// "ges", "gep", "gel", "gie" at the beginning
+		e.add("K", "J")
+		return i + 2
+	case (e.matches(i+1, "ER") || e.at(i+1) == 'Y') &&
+		!e.matches(0, "DANGER", "RANGER", "MANGER") &&
+		!e.matches(i-1, "E", "I") && !e.matches(i-1, "RGY", "OGY"):
+		// "ger", "gy"
+		e.add("K", "J")
+		return i + 2
+	case e.matches(i+1, "E", "I", "Y") || e.matches(i-1, "AGGI", "OGGI"):
+		switch {
+		case e.matches(0, "VAN ", "VON ") || e.matches(0, "SCH") || e.matches(i+1, "ET"):
+			// obviously Germanic
+			e.add("K")
+		case e.matches(i+1, "IER"):
+			e.add("J")
+		default:
+			e.add("J", "K")
+		}
+		return i + 2
+	case e.at(i+1) == 'G':
+		e.add("K")
+		return i + 2
+	}
+	e.add("K")
+	return i + 1
+}
+
+func (e *doubleMetaphone) encodeGH(i int) int {
+	switch {
+	case i > 0 && !e.isVowel(i-1):
+		e.add("K")
+	case i == 0:
+		if e.at(i+2) == 'I' {
+			e.add("J")
+		} else {
+			e.add("K")
+		}
+	case (i > 1 && e.matches(i-2, "B", "H", "D")) ||
+		(i > 2 && e.matches(i-3, "B", "H", "D")) ||
+		(i > 3 && e.matches(i-4, "B", "H")):
+		// Parker's rule, as in "Hugh"
+	case i > 2 && e.at(i-1) == 'U' && e.matches(i-3, "C", "G", "L", "R", "T"):
+		// "laugh", "McLaughlin", "cough", "rough", "tough"
+		e.add("F")
+	case e.at(i-1) != 'I':
+		e.add("K")
+	}
+	return i + 2
+}
+
+func (e *doubleMetaphone) encodeJ(i int) int {
+	if e.matches(i, "JOSE") || e.matches(0, "SAN ") {
+		// Spanish, as in "Jose" and "San Jacinto"
+		if (i == 0 && e.at(i+4) == ' ') || len(e.w) == 4 || e.matches(0, "SAN ") {
+			e.add("H")
+		} else {
+			e.add("J", "H")
+		}
+		return i + 1
+	}
+
+	switch {
+	case i == 0:
+		// "Yankelovich", "Jankelowicz"
+		e.add("J", "A")
+	case e.isVowel(i-1) && !e.slavoGermanic && (e.at(i+1) == 'A' || e.at(i+1) == 'O'):
+		// Spanish pronunciation of "bajador"
+		e.add("J", "H")
+	case e.isLast(i):
+		e.add("J", "")
+	case !e.matches(i+1, "L", "T", "K", "S", "N", "M", "B", "Z") && !e.matches(i-1, "S", "K", "L"):
+		e.add("J")
+	}
+	return e.skip(i, "J")
+}
+
+func (e *doubleMetaphone) encodeL(i int) int {
+	if e.at(i+1) != 'L' {
+		e.add("L")
+		return i + 1
+	}
+	n := len(e.w)
+	if (i == n-3 && e.matches(i-1, "ILLO", "ILLA", "ALLE")) ||
+		((e.matches(n-2, "AS", "OS") || e.matches(n-1, "A", "O")) && e.matches(i-1, "ALLE")) {
+		// Spanish, as in "cabrillo" and "gallegos"
+		e.addPrimary("L")
+	} else {
+		e.add("L")
+	}
+	return i + 2
+}
+
+func (e *doubleMetaphone) encodeS(i int) int {
+	switch {
+	case e.matches(i-1, "ISL", "YSL"):
+		// silent in "island", "isle", "carlisle"
+		return i + 1
+	case i == 0 && e.matches(i, "SUGAR"):
+		e.add("X", "S")
+		return i + 1
+	case e.matches(i, "SH"):
+		if e.matches(i+1, "HEIM", "HOEK", "HOLM", "HOLZ") {
+			// Germanic
+			e.add("S")
+		} else {
+			e.add("X")
+		}
+		return i + 2
+	case e.matches(i, "SIO", "SIA"):
+		// Italian and Armenian
+		if e.slavoGermanic {
+			e.add("S")
+		} else {
+			e.add("S", "X")
+		}
+		return i + 3
+	case (i == 0 && e.matches(i+1, "M", "N", "L", "W")) || e.matches(i+1, "Z"):
+		// German and anglicisations, as in "Smith" for "Schmidt" and
+		// "Snider" for "Schneider", and "sz" in Slavic languages
+		e.add("S", "X")
+		return e.skip(i, "Z")
+	case e.matches(i, "SC"):
+		return e.encodeSC(i)
+	}
+
+	if e.isLast(i) && e.matches(i-2, "AI", "OI") {
+		// French, as in "Resnais" and "Artois"
+		e.addAlternate("S")
+	} else {
+		e.add("S")
+	}
+	return e.skip(i, "SZ")
+}
+
+func (e *doubleMetaphone) encodeSC(i int) int {
+	switch {
+	case e.at(i+2) == 'H':
+		switch {
+		case e.matches(i+3, "ER", "EN"):
+			// Dutch, as in "Schermerhorn" and "Schenker"
+			e.add("X", "SK")
+		case e.matches(i+3, "OO", "UY", "ED", "EM"):
+			// Dutch, as in "school" and "schooner"
+			e.add("SK")
+		case i == 0 && !e.isVowel(3) && e.at(3) != 'W':
+			e.add("X", "S")
+		default:
+			e.add("X")
+		}
+	case e.matches(i+2, "I", "E", "Y"):
+		e.add("S")
+	default:
+		e.add("SK")
+	}
+	return i + 3
+}
+
+func (e *doubleMetaphone) encodeT(i int) int {
+	switch {
+	case e.matches(i, "TION"), e.matches(i, "TIA", "TCH"):
+		e.add("X")
+		return i + 3
+	case e.matches(i, "TH"), e.matches(i, "TTH"):
+		if e.matches(i+2, "OM", "AM") || e.matches(0, "VAN ", "VON ") || e.matches(0, "SCH") {
+			// "Thomas", "Thames" and Germanic names
+			e.add("T")
+		} else {
+			e.add("0", "T")
+		}
+		return i + 2
+	}
+	e.add("T")
+	return e.skip(i, "TD")
+}
+
+func (e *doubleMetaphone) encodeW(i int) int {
+	switch {
+	case e.matches(i, "WR"):
+		e.add("R")
+		return i + 2
+	case i == 0 && (e.isVowel(i+1) || e.matches(i, "WH")):
+		if e.isVowel(i + 1) {
+			// "Wasserman" should match "Vasserman"
+			e.add("A", "F")
+		} else {
+			// "Uomo" should match "Womo"
+			e.add("A")
+		}
+		return i + 1
+	case (e.isLast(i) && e.isVowel(i-1)) ||
+		e.matches(i-1, "EWSKI", "EWSKY", "OWSKI", "OWSKY") || e.matches(0, "SCH"):
+		// "Arnow" should match "Arnoff"
+		e.addAlternate("F")
+		return i + 1
+	case e.matches(i, "WICZ", "WITZ"):
+		// Polish, as in "Filipowicz"
+		e.add("TS", "FX")
+		return i + 4
+	}
+	return i + 1
+}
+
+func (e *doubleMetaphone) encodeX(i int) int {
+	if i == 0 {
+		// "Xavier"
+		e.add("S")
+		return i + 1
+	}
+	if !(e.isLast(i) && (e.matches(i-3, "IAU", "EAU") || e.matches(i-2, "AU", "OU"))) {
+		// but silent in French, as in "breaux"
+		e.add("KS")
+	}
+	return e.skip(i, "CX")
+}
+
+func (e *doubleMetaphone) encodeZ(i int) int {
+	if e.at(i+1) == 'H' {
+		// Chinese pinyin, as in "Zhao"
+		e.add("J")
+		return i + 2
+	}
+	if e.matches(i+1, "ZO", "ZI", "ZA") || (e.slavoGermanic && i > 0 && e.at(i-1) != 'T') {
+		e.add("S", "TS")
+	} else {
+		e.add("S")
+	}
+	return e.skip(i, "Z")
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,39 @@
+package phonetic
+
+import (
+	"testing"
+)
+
+var doubleMetaphoneData = [][]string{
+	{"Smith", "SM0", "XMT"},
+	{"Schmidt", "XMT", "SMT"},
+	{"Stephen", "STFN", "STFN"},
+	{"Jose", "HS", "HS"},
+	{"Xavier", "SF", "SFR"},
+	{"Filipowicz", "FLPT", "FLPF"},
+	{"Michael", "MKL", "MXL"},
+	{"Caesar", "SSR", "SSR"},
+	{"Wasserman", "ASRM", "FSRM"},
+	{"Arnow", "ARN", "ARNF"},
+	{"Gallegos", "KLKS", "KKS"},
+	{"Katherine", "K0RN", "KTRN"},
+	{"Catherine", "K0RN", "KTRN"},
+	{"Czerny", "SRN", "XRN"},
+	{"Hugh", "H", "H"},
+	{"cough", "KF", "KF"},
+	{"edge", "AJ", "AJ"},
+	{"Zhao", "J", "J"},
+	{"Mac Caffrey", "MKFR", "MKFR"},
+	{"Sugar", "XKR", "SKR"},
+	{"", "", ""},
+}
+
+func TestDoubleMetaphone(t *testing.T) {
+	for _, testCase := range doubleMetaphoneData {
+		primary, alternate := DoubleMetaphone(testCase[0])
+		if primary != testCase[1] || alternate != testCase[2] {
+			t.Errorf("DoubleMetaphone %v: Expected %v and %v, got %v and %v.",
+				testCase[0], testCase[1], testCase[2], primary, alternate)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package phonetic
+
+import "strings"
+
+// Metaphone computes the original Metaphone code of word, as described
+// by Lawrence Philips. Consonants are reduced to sixteen sounds, with
+// "0" standing for "th" and "X" for "sh", and vowels are only kept at
+// the beginning of the word, so "Thumb" becomes "0M".
+// Returns an empty string if word has no letters.
+func Metaphone(word string) string {
+	w := upperLetters(word)
+	if len(w) == 0 {
+		return ""
+	}
+
+	switch {
+	case hasPrefix(w, "AE", "GN", "KN", "PN", "WR"):
+		w = w[1:]
+	case w[0] == 'X':
+		w[0] = 'S'
+	case hasPrefix(w, "WH"):
+		w = append([]byte{'W'}, w[2:]...)
+	}
+
+	at := func(i int) byte {
+		if i < 0 || i >= len(w) {
+			return 0
+		}
+		return w[i]
+	}
+	isFrontVowel := func(c byte) bool {
+		return c == 'E' || c == 'I' || c == 'Y'
+	}
+
+	code := make([]byte, 0, len(w))
+	for i, c := range w {
+		if c == at(i-1) && c != 'C' {
+			continue
+		}
+		switch c {
+		case 'A', 'E', 'I', 'O', 'U':
+			if i == 0 {
+				code = append(code, c)
+			}
+		case 'B':
+			// silent in a final "mb", as in "dumb"
+			if !(i == len(w)-1 && at(i-1) == 'M') {
+				code = append(code, 'B')
+			}
+		case 'C':
+			switch {
+			case at(i+1) == 'I' && at(i+2) == 'A':
+				code = append(code, 'X')
+			case at(i+1) == 'H':
+				if at(i-1) == 'S' {
+					code = append(code, 'K')
+				} else {
+					code = append(code, 'X')
+				}
+			case isFrontVowel(at(i + 1)):
+				if at(i-1) != 'S' {
+					code = append(code, 'S')
+				}
+			default:
+				code = append(code, 'K')
+			}
+		case 'D':
+			if at(i+1) == 'G' && isFrontVowel(at(i+2)) {
+				code = append(code, 'J')
+			} else {
+				code = append(code, 'T')
+			}
+		case 'G':
+			switch {
+			case at(i+1) == 'H' && i+2 < len(w) && !isVowel(at(i+2)):
+				// silent in "gh" before a consonant, as in "night"
+			case at(i+1) == 'N' && (i+2 == len(w) || (at(i+2) == 'E' && at(i+3) == 'D' && i+4 == len(w))):
+				// silent in a final "gn" or "gned", as in "sign"
+			case at(i-1) == 'D' && isFrontVowel(at(i+1)):
+				// silent in "dge", as in "judge"
+			case isFrontVowel(at(i+1)) && at(i-1) != 'G':
+				code = append(code, 'J')
+			default:
+				code = append(code, 'K')
+			}
+		case 'H':
+			if isVowel(at(i+1)) && strings.IndexByte("CSPTG", at(i-1)) < 0 {
+				code = append(code, 'H')
+			}
+		case 'K':
+			if at(i-1) != 'C' {
+				code = append(code, 'K')
+			}
+		case 'P':
+			if at(i+1) == 'H' {
+				code = append(code, 'F')
+			} else {
+				code = append(code, 'P')
+			}
+		case 'Q':
+			code = append(code, 'K')
+		case 'S':
+			switch {
+			case at(i+1) == 'H':
+				code = append(code, 'X')
+			case at(i+1) == 'I' && (at(i+2) == 'O' || at(i+2) == 'A'):
+				code = append(code, 'X')
+			default:
+				code = append(code, 'S')
+			}
+		case 'T':
+			switch {
+			case at(i+1) == 'I' && (at(i+2) == 'O' || at(i+2) == 'A'):
+				code = append(code, 'X')
+			case at(i+1) == 'H':
+				code = append(code, '0')
+			case at(i+1) == 'C' && at(i+2) == 'H':
+				// silent in "tch", as in "witch"
+			default:
+				code = append(code, 'T')
+			}
+		case 'V':
+			code = append(code, 'F')
+		case 'W', 'Y':
+			if isVowel(at(i + 1)) {
+				code = append(code, c)
+			}
+		case 'X':
+			code = append(code, 'K', 'S')
+		case 'Z':
+			code = append(code, 'S')
+		default:
+			code = append(code, c)
+		}
+	}
+	return string(code)
+}
+
+func hasPrefix(w []byte, prefixes ...string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(string(w), prefix) {
+			return true
+		}
+	}
+	return false
+}
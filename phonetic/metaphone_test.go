@@ -0,0 +1,24 @@
+package phonetic
+
+import (
+	"testing"
+)
+
+var metaphoneData = [][]string{
+	{"Knight", "NT"},
+	{"Thumb", "0M"},
+	{"Stephen", "STFN"},
+	{"Steven", "STFN"},
+	{"Philip", "FLP"},
+	{"Xavier", "SFR"},
+	{"Wright", "RT"},
+	{"accept", "AKSPT"},
+	{"science", "SNS"},
+	{"judge", "JJ"},
+	{"nation", "NXN"},
+	{"", ""},
+}
+
+func TestMetaphone(t *testing.T) {
+	assertEncodings(t, "Metaphone", Metaphone, metaphoneData)
+}
@@ -0,0 +1,98 @@
+package phonetic
+
+import "strings"
+
+// nysiisLength is the length NYSIIS codes are truncated to.
+const nysiisLength = 6
+
+var (
+	nysiisPrefixes = [][2]string{
+		{"MAC", "MCC"}, {"KN", "NN"}, {"K", "C"}, {"PH", "FF"}, {"PF", "FF"}, {"SCH", "SSS"},
+	}
+	nysiisSuffixes = [][2]string{
+		{"EE", "Y"}, {"IE", "Y"}, {"DT", "D"}, {"RT", "D"}, {"RD", "D"}, {"NT", "D"}, {"ND", "D"},
+	}
+)
+
+// NYSIIS computes the code of word under the New York State Identification
+// and Intelligence System, which keeps the positions of vowels, coded as
+// "A", and so distinguishes more names than Soundex: "Stephen" and
+// "Steven" both code as "STAFAN". Codes are at most six letters long.
+// Returns an empty string if word has no letters.
+func NYSIIS(word string) string {
+	name := string(upperLetters(word))
+	if name == "" {
+		return ""
+	}
+	for _, p := range nysiisPrefixes {
+		if strings.HasPrefix(name, p[0]) {
+			name = p[1] + name[len(p[0]):]
+			break
+		}
+	}
+	for _, s := range nysiisSuffixes {
+		if strings.HasSuffix(name, s[0]) {
+			name = name[:len(name)-len(s[0])] + s[1]
+			break
+		}
+	}
+
+	chars := []byte(name)
+	at := func(i int) byte {
+		if i >= len(chars) {
+			return ' '
+		}
+		return chars[i]
+	}
+	key := []byte{chars[0]}
+	for i := 1; i < len(chars); i++ {
+		copy(chars[i:], nysiisTranscode(chars[i-1], chars[i], at(i+1), at(i+2)))
+		if chars[i] != chars[i-1] {
+			key = append(key, chars[i])
+		}
+	}
+
+	if len(key) > 1 && key[len(key)-1] == 'S' {
+		key = key[:len(key)-1]
+	}
+	if n := len(key); n > 2 && key[n-2] == 'A' && key[n-1] == 'Y' {
+		key = append(key[:n-2], 'Y')
+	}
+	if len(key) > 1 && key[len(key)-1] == 'A' {
+		key = key[:len(key)-1]
+	}
+	if len(key) > nysiisLength {
+		key = key[:nysiisLength]
+	}
+	return string(key)
+}
+
+// nysiisTranscode returns the replacement for the letter cur, which
+// overwrites it and, for some rules, the letters following it.
+func nysiisTranscode(prev, cur, next, afterNext byte) string {
+	switch {
+	case cur == 'E' && next == 'V':
+		return "AF"
+	case isVowel(cur):
+		return "A"
+	case cur == 'Q':
+		return "G"
+	case cur == 'Z':
+		return "S"
+	case cur == 'M':
+		return "N"
+	case cur == 'K' && next == 'N':
+		return "NN"
+	case cur == 'K':
+		return "C"
+	case cur == 'S' && next == 'C' && afterNext == 'H':
+		return "SSS"
+	case cur == 'P' && next == 'H':
+		return "FF"
+	case cur == 'H' && (!isVowel(prev) || !isVowel(next)):
+		return string(prev)
+	case cur == 'W' && isVowel(prev):
+		return string(prev)
+	}
+	return string(cur)
+}
@@ -0,0 +1,24 @@
+package phonetic
+
+import (
+	"testing"
+)
+
+var nysiisData = [][]string{
+	{"Stephen", "STAFAN"},
+	{"Steven", "STAFAN"},
+	{"Bishop", "BASAP"},
+	{"Carlson", "CARLSA"},
+	{"Knight", "NAGT"},
+	{"Mitchell", "MATCAL"},
+	{"Brian", "BRAN"},
+	{"Brown", "BRAN"},
+	{"Greene", "GRAN"},
+	{"Macintosh", "MCANT"},
+	{"Kelly", "CALY"},
+	{"", ""},
+}
+
+func TestNYSIIS(t *testing.T) {
+	assertEncodings(t, "NYSIIS", NYSIIS, nysiisData)
+}
@@ -0,0 +1,112 @@
+// Package phonetic provides phonetic encoders, which reduce words to codes
+// describing how they sound, so that words spelled differently but
+// pronounced alike, such as "Stephen" and "Steven", get the same code.
+//
+// The encoders consider the letters of a word only and ignore every other
+// character. Latin letters with diacritics are folded to their base
+// letters, so "Müller" codes like "Muller"; transliterate words written
+// in other scripts to Latin before encoding them.
+package phonetic
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Encoder computes the phonetic code of a word.
+type Encoder interface {
+	Encode(word string) string
+}
+
+// MultiEncoder is an Encoder that can produce alternative codes
+// for words with more than one plausible pronunciation.
+type MultiEncoder interface {
+	Encoder
+	// EncodeAll returns the codes of word, most likely first.
+	EncodeAll(word string) []string
+}
+
+// EncoderFunc adapts an ordinary function to the Encoder interface.
+type EncoderFunc func(string) string
+
+// Encode calls f(word).
+func (f EncoderFunc) Encode(word string) string {
+	return f(word)
+}
+
+// The encoders of this package.
+var (
+	SoundexEncoder         Encoder      = EncoderFunc(Soundex)
+	RefinedSoundexEncoder  Encoder      = EncoderFunc(RefinedSoundex)
+	MetaphoneEncoder       Encoder      = EncoderFunc(Metaphone)
+	DoubleMetaphoneEncoder MultiEncoder = doubleMetaphoneEncoder{}
+	NYSIISEncoder          Encoder      = EncoderFunc(NYSIIS)
+	CaverphoneEncoder      Encoder      = EncoderFunc(Caverphone)
+	ColognePhoneticEncoder Encoder      = EncoderFunc(ColognePhonetic)
+	PhonexEncoder          Encoder      = EncoderFunc(Phonex)
+	DaitchMokotoffEncoder  MultiEncoder = daitchMokotoffEncoder{}
+)
+
+// Codes returns the distinct, non-empty codes the encoder produces
+// for word, using EncodeAll if the encoder is a MultiEncoder.
+func Codes(encoder Encoder, word string) []string {
+	var all []string
+	if multi, ok := encoder.(MultiEncoder); ok {
+		all = multi.EncodeAll(word)
+	} else {
+		all = []string{encoder.Encode(word)}
+	}
+
+	codes := make([]string, 0, len(all))
+	for _, code := range all {
+		if code != "" && !contains(codes, code) {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
+
+func contains(codes []string, code string) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// upperLetters returns the letters of s in upper case, folding Latin
+// letters with diacritics to ASCII and dropping every other character.
+func upperLetters(s string) []byte {
+	letters := make([]byte, 0, len(s))
+	for _, r := range s {
+		letters = appendLetter(letters, unicode.ToUpper(r))
+	}
+	return letters
+}
+
+// appendLetter appends the ASCII form of the upper case letter r to letters.
+func appendLetter(letters []byte, r rune) []byte {
+	if r >= 'A' && r <= 'Z' {
+		return append(letters, byte(r))
+	}
+	return append(letters, latinFolds[r]...)
+}
+
+// latinFolds maps upper case Latin letters with diacritics,
+// and ligatures, to ASCII.
+var latinFolds = map[rune]string{
+	'À': "A", 'Á': "A", 'Â': "A", 'Ã': "A", 'Ä': "A", 'Å': "A", 'Ą': "A", 'Ă': "A",
+	'Æ': "AE", 'Ç': "C", 'Ć': "C", 'Č': "C", 'Ď': "D", 'Đ': "D", 'Ð': "D",
+	'È': "E", 'É': "E", 'Ê': "E", 'Ë': "E", 'Ę': "E", 'Ě': "E",
+	'Ğ': "G", 'Ì': "I", 'Í': "I", 'Î': "I", 'Ï': "I", 'İ': "I",
+	'Ł': "L", 'Ľ': "L", 'Ñ': "N", 'Ń': "N", 'Ň': "N",
+	'Ò': "O", 'Ó': "O", 'Ô': "O", 'Õ': "O", 'Ö': "O", 'Ø': "O", 'Ő': "O", 'Œ': "OE",
+	'Ř': "R", 'Ś': "S", 'Š': "S", 'Ş': "S", 'ß': "SS", 'Ť': "T", 'Ţ': "T", 'Þ': "TH",
+	'Ù': "U", 'Ú': "U", 'Û': "U", 'Ü': "U", 'Ů': "U", 'Ű': "U",
+	'Ý': "Y", 'Ÿ': "Y", 'Ź': "Z", 'Ż': "Z", 'Ž': "Z",
+}
+
+func isVowel(c byte) bool {
+	return c != 0 && strings.IndexByte("AEIOU", c) >= 0
+}
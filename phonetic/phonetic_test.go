@@ -0,0 +1,33 @@
+package phonetic
+
+import (
+	"reflect"
+	"testing"
+)
+
+func assertEncodings(t *testing.T, name string, encode func(string) string, data [][]string) {
+	for _, testCase := range data {
+		if actual := encode(testCase[0]); actual != testCase[1] {
+			t.Errorf("%v %v: Expected %v, got %v.", name, testCase[0], testCase[1], actual)
+		}
+	}
+}
+
+func TestCodes(t *testing.T) {
+	codesData := []struct {
+		encoder  Encoder
+		word     string
+		expected []string
+	}{
+		{SoundexEncoder, "Robert", []string{"R163"}},
+		{SoundexEncoder, "", []string{}},
+		{DoubleMetaphoneEncoder, "Smith", []string{"SM0", "XMT"}},
+		{DoubleMetaphoneEncoder, "Steven", []string{"STFN"}},
+		{EncoderFunc(func(string) string { return "X" }), "anything", []string{"X"}},
+	}
+	for _, testCase := range codesData {
+		if actual := Codes(testCase.encoder, testCase.word); !reflect.DeepEqual(actual, testCase.expected) {
+			t.Errorf("Codes %v: Expected %v, got %v.", testCase.word, testCase.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package phonetic
+
+import (
+	"strings"
+	"unicode"
+)
+
+// phonexRules are the rewrite rules of Phonex, in the order they are
+// applied. Digits stand for French sounds: "1" for "an", "2" for "oi",
+// "3" for "ou", "4" for "in" and "5" for "ch".
+var phonexRules = compileRewriteRules(
+	"Y", "I",
+	"^H", "",
+	"([^PCS])H", "$1",
+	"PH", "F",
+	"G(AI?[NM])", "K$1",
+	"[AE]I[NM]([AEIOU])", "YN$1",
+	"EAU", "O",
+	"OUA", "2",
+	"[AE]I[NM]", "4",
+	"[ÉÈÊ]", "Y",
+	"[AE]I", "Y",
+	"ER", "YR",
+	"ESS", "YSS",
+	"ET", "YT",
+	"[AE][NM]([^AEIOU1234]|$)", "1$1",
+	"IN([^AEIOU1234]|$)", "4$1",
+	"([AEIOUY1234])S([AEIOUY1234])", "${1}Z$2",
+	"OE|EU", "E",
+	"AU", "O",
+	"OI", "2",
+	"OU", "3",
+	"S?CH|SH", "5",
+	"SS", "S",
+	"SC([EI])", "S$1",
+	"C([EI])", "S$1",
+	"QU?|GU|C", "K",
+	"G([AO])", "K$1",
+	"A", "O",
+	"D", "T",
+	"P", "T",
+	"J", "G",
+	"[BV]", "F",
+	"M", "N",
+)
+
+// Phonex computes the Phonex code of word, a phonetic algorithm designed
+// by Frédéric Brouard for French names. Groups of letters are reduced to
+// the sounds they make in French, so "Dupont" and "Dupond" both code as
+// "TUTON", and "Rousseau" and "Roussot" as "R3SO". The code is returned in
+// its letter form rather than converted to a number as in the original
+// description.
+// Returns an empty string if word has no letters.
+func Phonex(word string) string {
+	letters := make([]byte, 0, len(word))
+	for _, r := range word {
+		r = unicode.ToUpper(r)
+		if r == 'É' || r == 'È' || r == 'Ê' {
+			// kept for the rule coding the sound "é"
+			letters = append(letters, string(r)...)
+			continue
+		}
+		letters = appendLetter(letters, r)
+	}
+	code := string(letters)
+	if code == "" {
+		return ""
+	}
+
+	for _, rule := range phonexRules {
+		code = rule.pattern.ReplaceAllString(code, rule.replacement)
+	}
+
+	deduped := make([]byte, 0, len(code))
+	for i := 0; i < len(code); i++ {
+		if i == 0 || code[i] != code[i-1] {
+			deduped = append(deduped, code[i])
+		}
+	}
+	return strings.TrimRight(string(deduped), "TX")
+}
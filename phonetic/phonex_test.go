@@ -0,0 +1,26 @@
+package phonetic
+
+import (
+	"testing"
+)
+
+var phonexData = [][]string{
+	{"Dupont", "TUTON"},
+	{"Dupond", "TUTON"},
+	{"Rousseau", "R3SO"},
+	{"Roussot", "R3SO"},
+	{"Faure", "FORE"},
+	{"Phaure", "FORE"},
+	{"Moreau", "NORO"},
+	{"Morot", "NORO"},
+	{"Bernard", "FYRNOR"},
+	{"Bernhard", "FYRNOR"},
+	{"Chevalier", "5EFOLIYR"},
+	{"François", "FR1K2S"},
+	{"Quentin", "K1T4"},
+	{"", ""},
+}
+
+func TestPhonex(t *testing.T) {
+	assertEncodings(t, "Phonex", Phonex, phonexData)
+}
@@ -0,0 +1,61 @@
+package phonetic
+
+// soundexCodes maps the letters A to Z to their Soundex digits.
+// Vowels, H, W and Y are coded as 0 and never appear in a code.
+const soundexCodes = "01230120022455012623010202"
+
+// refinedSoundexCodes maps the letters A to Z to their Refined Soundex digits.
+const refinedSoundexCodes = "01360240043788015936020505"
+
+// Soundex computes the American Soundex code of word: its first letter
+// followed by three digits coding the consonants that follow, such as
+// "R163" for both "Robert" and "Rupert". Adjacent consonants with the same
+// digit, including those separated by H or W, are coded once.
+// Returns an empty string if word has no letters.
+func Soundex(word string) string {
+	letters := upperLetters(word)
+	if len(letters) == 0 {
+		return ""
+	}
+
+	code := []byte{letters[0]}
+	last := soundexCodes[letters[0]-'A']
+	for _, c := range letters[1:] {
+		digit := soundexCodes[c-'A']
+		if digit != '0' && digit != last {
+			code = append(code, digit)
+			if len(code) == 4 {
+				break
+			}
+		}
+		if c != 'H' && c != 'W' {
+			last = digit
+		}
+	}
+	for len(code) < 4 {
+		code = append(code, '0')
+	}
+	return string(code)
+}
+
+// RefinedSoundex computes the Refined Soundex code of word, a variant of
+// Soundex with finer groups of consonants that codes vowels and is not
+// truncated, so fewer unrelated words share a code.
+// Returns an empty string if word has no letters.
+func RefinedSoundex(word string) string {
+	letters := upperLetters(word)
+	if len(letters) == 0 {
+		return ""
+	}
+
+	code := []byte{letters[0]}
+	var last byte
+	for _, c := range letters {
+		digit := refinedSoundexCodes[c-'A']
+		if digit != last {
+			code = append(code, digit)
+		}
+		last = digit
+	}
+	return string(code)
+}
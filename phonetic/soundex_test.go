@@ -0,0 +1,39 @@
+package phonetic
+
+import (
+	"testing"
+)
+
+var soundexData = [][]string{
+	{"Robert", "R163"},
+	{"Rupert", "R163"},
+	{"Rubin", "R150"},
+	{"Ashcraft", "A261"},
+	{"Tymczak", "T522"},
+	{"Pfister", "P236"},
+	{"Honeyman", "H555"},
+	{"Lee", "L000"},
+	{"o'hara", "O600"},
+	{"", ""},
+	{"123", ""},
+}
+
+var refinedSoundexData = [][]string{
+	{"testing", "T6036084"},
+	{"The", "T60"},
+	{"quick", "Q503"},
+	{"brown", "B1908"},
+	{"fox", "F205"},
+	{"jumped", "J408106"},
+	{"lazy", "L7050"},
+	{"dogs", "D6043"},
+	{"", ""},
+}
+
+func TestSoundex(t *testing.T) {
+	assertEncodings(t, "Soundex", Soundex, soundexData)
+}
+
+func TestRefinedSoundex(t *testing.T) {
+	assertEncodings(t, "RefinedSoundex", RefinedSoundex, refinedSoundexData)
+}
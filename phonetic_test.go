@@ -0,0 +1,58 @@
+package fuzzy
+
+import (
+	"testing"
+
+	"github.com/paul-mannino/go-fuzzywuzzy/phonetic"
+)
+
+func TestPhoneticRatio(t *testing.T) {
+	assertRatioIs100(t, "PhoneticRatio", "Stephen", "Steven", PhoneticRatio("Stephen", "Steven"))
+	assertRatioIs100(t, "PhoneticRatio", "Schmidt", "Smith", PhoneticRatio("Schmidt", "Smith"))
+	assertRatioIs100(t, "PhoneticRatio", "Katherine Smyth", "smith, catherine", PhoneticRatio("Katherine Smyth", "smith, catherine"))
+	assertRatioIs100(t, "PhoneticRatio", "Zoë", "Zoe", PhoneticRatio("Zoë", "Zoe"))
+	assertRatioIsNot100(t, "PhoneticRatio", "Stephen", "Stella", PhoneticRatio("Stephen", "Stella"))
+	assertRatio(t, "PhoneticRatio", "", "Steven", 0, PhoneticRatio("", "Steven"))
+	assertRatio(t, "PhoneticRatio", "!!", "Steven", 0, PhoneticRatio("!!", "Steven"))
+
+	if Ratio("Schmidt", "Smith") >= PhoneticRatio("Schmidt", "Smith") {
+		t.Error("Expected PhoneticRatio to score Schmidt and Smith higher than Ratio")
+	}
+}
+
+func TestPhoneticScorer(t *testing.T) {
+	soundex := PhoneticScorer(phonetic.SoundexEncoder)
+	assertRatioIs100(t, "Soundex scorer", "Robert", "Rupert", soundex("Robert", "Rupert"))
+	assertRatioIsNot100(t, "Soundex scorer", "Robert", "Rubin", soundex("Robert", "Rubin"))
+
+	choices := []string{"Jon Smyth", "Stephanie Stone", "Steven Schmidt"}
+	match, err := ExtractOne("Stephen Smith", choices, PhoneticRatio)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if match.Match != "Steven Schmidt" || match.Score != 100 {
+		t.Errorf("Expected {Steven Schmidt 100}, got %v", match)
+	}
+}
+
+func TestPhoneticScorerEuropean(t *testing.T) {
+	cologne := PhoneticScorer(phonetic.ColognePhoneticEncoder)
+	assertRatioIs100(t, "Cologne scorer", "Hans Müller", "Mueller, Hanns", cologne("Hans Müller", "Mueller, Hanns"))
+	assertRatioIs100(t, "Cologne scorer", "Meyer", "Maier", cologne("Meyer", "Maier"))
+
+	phonex := PhoneticScorer(phonetic.PhonexEncoder)
+	assertRatioIs100(t, "Phonex scorer", "Jean Dupont", "Jean Dupond", phonex("Jean Dupont", "Jean Dupond"))
+
+	daitchMokotoff := PhoneticScorer(phonetic.DaitchMokotoffEncoder)
+	assertRatioIs100(t, "Daitch-Mokotoff scorer", "Szlachter", "Schlachter", daitchMokotoff("Szlachter", "Schlachter"))
+	assertRatioIs100(t, "Daitch-Mokotoff scorer", "Peters", "Petersz", daitchMokotoff("Peters", "Petersz"))
+
+	choices := []string{"Schneider", "Schmidt", "Schulz"}
+	match, err := ExtractOne("Schmitt", choices, cologne)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if match.Match != "Schmidt" || match.Score != 100 {
+		t.Errorf("Expected {Schmidt 100}, got %v", match)
+	}
+}
@@ -0,0 +1,208 @@
+package fuzzy
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Stage is a single string transformation within a Pipeline.
+type Stage func(string) string
+
+// Pipeline is a processor assembled from a sequence of stages, which
+// are applied in the order they were added. The builder methods
+// return a new Pipeline, so a pipeline can be shared and extended
+// without affecting other pipelines built from it.
+//
+// The Process method has the form f(string)->string, so it can be
+// passed as a processor to Extract, ExtractOne and ExtractWithoutOrder:
+//
+//	p := NewPipeline().Transliterate().Lowercase().StripPunctuation().CollapseWhitespace()
+//	matches, err := Extract(query, choices, 5, p.Process)
+type Pipeline struct {
+	stages []Stage
+}
+
+// NewPipeline creates a pipeline from the given stages.
+func NewPipeline(stages ...Stage) *Pipeline {
+	return &Pipeline{stages: append([]Stage{}, stages...)}
+}
+
+// Process runs s through every stage of the pipeline.
+func (p *Pipeline) Process(s string) string {
+	for _, stage := range p.stages {
+		s = stage(s)
+	}
+	return s
+}
+
+// Then returns a pipeline with the given stage appended.
+func (p *Pipeline) Then(stage Stage) *Pipeline {
+	stages := make([]Stage, len(p.stages), len(p.stages)+1)
+	copy(stages, p.stages)
+	return &Pipeline{stages: append(stages, stage)}
+}
+
+// Custom returns a pipeline with the custom processing function appended.
+func (p *Pipeline) Custom(processor func(string) string) *Pipeline {
+	return p.Then(processor)
+}
+
+// Trim returns a pipeline that also removes leading and trailing whitespace.
+func (p *Pipeline) Trim() *Pipeline {
+	return p.Then(strings.TrimSpace)
+}
+
+// Lowercase returns a pipeline that also lowercases strings.
+func (p *Pipeline) Lowercase() *Pipeline {
+	return p.Then(strings.ToLower)
+}
+
+// FoldCase returns a pipeline that also applies Unicode full case folding.
+func (p *Pipeline) FoldCase() *Pipeline {
+	return p.Then(FoldCase)
+}
+
+// Normalize returns a pipeline that also converts strings to
+// the given Unicode normalization form.
+func (p *Pipeline) Normalize(form NormalizationForm) *Pipeline {
+	return p.Then(func(s string) string {
+		return Normalize(s, form)
+	})
+}
+
+// Transliterate returns a pipeline that also transliterates
+// non-ASCII characters.
+func (p *Pipeline) Transliterate() *Pipeline {
+	return p.Then(Transliterate)
+}
+
+// ASCIIOnly returns a pipeline that also removes non-ASCII characters.
+func (p *Pipeline) ASCIIOnly() *Pipeline {
+	return p.Then(ASCIIOnly)
+}
+
+// StripPunctuation returns a pipeline that also replaces every rune
+// that is not a letter or number with a space.
+func (p *Pipeline) StripPunctuation() *Pipeline {
+	return p.Then(StripPunctuation)
+}
+
+// CollapseWhitespace returns a pipeline that also collapses runs of
+// whitespace into single spaces and trims the result.
+func (p *Pipeline) CollapseWhitespace() *Pipeline {
+	return p.Then(CollapseWhitespace)
+}
+
+// RemoveStopwords returns a pipeline that also removes the given words
+// (see Stopwords.Remove).
+func (p *Pipeline) RemoveStopwords(words ...string) *Pipeline {
+	return p.Then(NewStopwords(words...).Remove)
+}
+
+// RemoveStopwordSet returns a pipeline that also removes stopwords
+// (see Stopwords.Remove).
+func (p *Pipeline) RemoveStopwordSet(stopwords *Stopwords) *Pipeline {
+	return p.Then(stopwords.Remove)
+}
+
+// NormalizeNumbers returns a pipeline that also normalizes numbers
+// (see NormalizeNumbers).
+func (p *Pipeline) NormalizeNumbers() *Pipeline {
+	return p.Then(NormalizeNumbers)
+}
+
+var numberWords = map[string]int{
+	"zero": 0, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+	"eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14, "fifteen": 15,
+	"sixteen": 16, "seventeen": 17, "eighteen": 18, "nineteen": 19,
+	"twenty": 20, "thirty": 30, "forty": 40, "fifty": 50,
+	"sixty": 60, "seventy": 70, "eighty": 80, "ninety": 90,
+	"first": 1, "third": 3, "fourth": 4, "fifth": 5,
+	"sixth": 6, "seventh": 7, "eighth": 8, "ninth": 9, "tenth": 10,
+	"eleventh": 11, "twelfth": 12, "thirteenth": 13, "fourteenth": 14, "fifteenth": 15,
+	"sixteenth": 16, "seventeenth": 17, "eighteenth": 18, "nineteenth": 19,
+	"twentieth": 20, "thirtieth": 30, "fortieth": 40, "fiftieth": 50,
+	"sixtieth": 60, "seventieth": 70, "eightieth": 80, "ninetieth": 90,
+}
+
+// numberUnitWords are number words only read as numbers after a dash, as
+// in "twenty-second": on their own they are ordinary words too.
+var numberUnitWords = map[string]int{"second": 2}
+
+// NormalizeNumbers rewrites the numbers in s as plain digits, so that
+// differently written numbers compare equal. English number words up to
+// ninety-nine ("twenty-one", "third") become digits, ordinal suffixes
+// ("3rd") and thousands separators ("1,000") are removed, and leading
+// zeros are dropped. "second" is left alone, as in "wait a second",
+// unless it follows a dash ("twenty-second"). Tokens are rejoined with
+// single spaces.
+func NormalizeNumbers(s string) string {
+	tokens := strings.Fields(s)
+	for i, token := range tokens {
+		tokens[i] = normalizeNumber(token)
+	}
+	return strings.Join(tokens, " ")
+}
+
+func normalizeNumber(token string) string {
+	lower := strings.ToLower(token)
+	if n, ok := numberWords[lower]; ok {
+		return strconv.Itoa(n)
+	}
+	if dash := strings.IndexByte(lower, '-'); dash > 0 {
+		tens, ok1 := numberWords[lower[:dash]]
+		units, ok2 := numberWords[lower[dash+1:]]
+		if !ok2 {
+			units, ok2 = numberUnitWords[lower[dash+1:]]
+		}
+		if ok1 && ok2 && tens >= 20 && tens%10 == 0 && units > 0 && units < 10 {
+			return strconv.Itoa(tens + units)
+		}
+	}
+
+	digits := lower
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		if strings.HasSuffix(digits, suffix) && len(digits) > len(suffix) {
+			digits = strings.TrimSuffix(digits, suffix)
+			break
+		}
+	}
+	digits = removeThousandsSeparators(digits)
+	if !isDigits(digits) {
+		return token
+	}
+	trimmed := strings.TrimLeft(digits, "0")
+	if trimmed == "" {
+		return "0"
+	}
+	return trimmed
+}
+
+func removeThousandsSeparators(s string) string {
+	groups := strings.Split(s, ",")
+	if len(groups) == 1 {
+		return s
+	}
+	if len(groups[0]) == 0 || len(groups[0]) > 3 {
+		return s
+	}
+	for _, group := range groups[1:] {
+		if len(group) != 3 {
+			return s
+		}
+	}
+	return strings.Join(groups, "")
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package fuzzy
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPipeline(t *testing.T) {
+	p := NewPipeline().Trim().Transliterate().Lowercase().StripPunctuation().CollapseWhitespace()
+	s := "  Beyoncé -- Live at  Zürich! "
+	expected := "beyonce live at zurich"
+	if actual := p.Process(s); actual != expected {
+		t.Errorf("Pipeline %v: Expected %v, got %v.", s, expected, actual)
+	}
+
+	// extending a pipeline does not affect the original
+	extended := p.RemoveStopwords("at", "live")
+	if actual := extended.Process(s); actual != "beyonce zurich" {
+		t.Errorf("Pipeline %v: Expected %v, got %v.", s, "beyonce zurich", actual)
+	}
+	if actual := p.Process(s); actual != expected {
+		t.Errorf("Pipeline %v: Expected %v, got %v.", s, expected, actual)
+	}
+
+	custom := NewPipeline(strings.ToUpper).Custom(func(s string) string {
+		return strings.Replace(s, "&", "AND", -1)
+	})
+	if actual := custom.Process("r&b"); actual != "RANDB" {
+		t.Errorf("Pipeline %v: Expected %v, got %v.", "r&b", "RANDB", actual)
+	}
+}
+
+func TestPipelineMatchesCleanse(t *testing.T) {
+	p := NewPipeline().ASCIIOnly().Trim().StripPunctuation().Lowercase()
+	for _, testCase := range cleanseData {
+		s := testCase[0].(string)
+		if p.Process(s) != Cleanse(s, true) {
+			t.Errorf("Pipeline %v: Expected %v, got %v.", s, Cleanse(s, true), p.Process(s))
+		}
+	}
+}
+
+func TestPipelineAsProcessor(t *testing.T) {
+	p := NewPipeline().Transliterate().Lowercase().StripPunctuation().CollapseWhitespace()
+	choices := []string{"Zurich Opera House", "Zürich Tonhalle", "Zug Theater"}
+	scorer := func(s1, s2 string) int {
+		return Ratio(s1, s2)
+	}
+	best, err := ExtractOne("ZURICH TONHALLE", choices, scorer, p.Process)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if best.Match != choices[1] || best.Score != 100 {
+		t.Errorf("Expected {%v 100}. Got %v", choices[1], *best)
+	}
+}
+
+var normalizeNumbersData = [][]string{
+	{"Twenty-One Pilots", "21 Pilots"},
+	{"the 3rd annual 1,000 mile race", "the 3 annual 1000 mile race"},
+	{"Third Annual Race", "3 Annual Race"},
+	{"agent 007", "agent 7"},
+	{"with 0 regrets", "with 0 regrets"},
+	{"1,00 items", "1,00 items"},
+	{"wait a second", "wait a second"},
+	{"Twenty-Second Street", "22 Street"},
+	{"2nd Avenue", "2 Avenue"},
+}
+
+func TestNormalizeNumbers(t *testing.T) {
+	for _, testCase := range normalizeNumbersData {
+		if actual := NormalizeNumbers(testCase[0]); actual != testCase[1] {
+			t.Errorf("NormalizeNumbers %v: Expected %v, got %v.", testCase[0], testCase[1], actual)
+		}
+	}
+}
@@ -204,14 +204,14 @@ func Dedupe(sliceWithDupes []string, args ...interface{}) ([]string, error) {
 	for i, arg := range args {
 		switch i {
 		case 0:
-			t, err := arg.(int)
-			if err {
+			t, ok := arg.(int)
+			if !ok {
 				return nil, errors.New("expected first optional argument to be an integer")
 			}
 			threshold = t
 		case 1:
-			s, err := arg.(func(string, string) int)
-			if err {
+			s, ok := arg.(func(string, string) int)
+			if !ok {
 				return nil, errors.New("expected second optional argument to be a function of the form f(string,string)->int")
 			}
 			scorer = s
@@ -230,9 +230,13 @@ func Dedupe(sliceWithDupes []string, args ...interface{}) ([]string, error) {
 				filtered = append(filtered, m)
 			}
 		}
-		if len(filtered) == 1 {
+		switch len(filtered) {
+		case 0:
+			// not even elem itself scores above the threshold
+			extracted = append(extracted, elem)
+		case 1:
 			extracted = append(extracted, filtered[0].Match)
-		} else {
+		default:
 			altPoints := alphaLengthSortPairs(filtered)
 			sort.Sort(altPoints)
 			extracted = append(extracted, altPoints[0].Match)
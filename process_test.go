@@ -109,6 +109,29 @@ func TestDedupe(t *testing.T) {
 	}
 }
 
+func TestDedupeOptionalArgs(t *testing.T) {
+	sliceWithDupes := []string{"Frodo Baggins", "Frodo Baggins!", "Tom Sawyer"}
+	res, err := Dedupe(sliceWithDupes, 90, Ratio)
+	if err != nil {
+		t.Fatalf("not expecting an error for an int threshold and a scorer, got %v", err)
+	}
+	if len(res) != 2 {
+		t.Errorf("expecting Dedupe to remove one string from slice, got %v", res)
+	}
+	// nothing scores above 100
+	res, err = Dedupe(sliceWithDupes, 100, Ratio)
+	if err != nil || len(res) != len(sliceWithDupes) {
+		t.Errorf("not expecting Dedupe to remove any strings from slice, got %v, %v", res, err)
+	}
+
+	if _, err := Dedupe(sliceWithDupes, "90"); err == nil {
+		t.Error("expecting an error for a threshold that is not an int")
+	}
+	if _, err := Dedupe(sliceWithDupes, 90, "Ratio"); err == nil {
+		t.Error("expecting an error for a scorer that is not a function")
+	}
+}
+
 func assertMatch(t *testing.T, query, expectedMatch, actualMatch string) {
 	if expectedMatch != actualMatch {
 		t.Errorf("expecting [%v] to find match of [%v], actual match was [%v]", query, expectedMatch, actualMatch)
@@ -0,0 +1,84 @@
+package fuzzy
+
+import "math"
+
+// SeqRatio computes a score of how close two sequences of strings are,
+// like Ratio does for strings of characters, as python-Levenshtein's
+// seqratio: the elements are edited as a whole, but substituting an
+// element with a similar one costs less than with a different one.
+// Inserting or deleting an element costs 1 and substituting a with b
+// costs 2*(1-r), where r is the Ratio of a and b in [0,1], so that
+// sequences of distinct elements get the same score as Ratio of strings
+// with one character per element. The order of elements matters; use
+// SetRatio to ignore it.
+// Returns an integer score [0,100], higher score indicates that the
+// sequences are closer.
+func SeqRatio(seq1, seq2 []string) int {
+	lenSum := len(seq1) + len(seq2)
+	if lenSum == 0 {
+		return 0
+	}
+	return similarityScore((float64(lenSum) - seqDistance(seq1, seq2)) / float64(lenSum))
+}
+
+// SetRatio computes a score similar to SeqRatio, except the order of
+// elements does not matter, as python-Levenshtein's setratio: each
+// element of seq1 is paired with an element of seq2 so that the total
+// Ratio of the pairs is as high as possible, using the Hungarian
+// algorithm, and elements left over count as inserted or deleted.
+// Returns an integer score [0,100].
+func SetRatio(seq1, seq2 []string) int {
+	lenSum := len(seq1) + len(seq2)
+	if len(seq1) == 0 || len(seq2) == 0 {
+		return 0
+	}
+	// ratios are scaled to integers for the assignment
+	const scale = 10000
+	ratios := make([][]float64, len(seq1))
+	scores := make([][]int, len(seq1))
+	for i, s1 := range seq1 {
+		ratios[i] = make([]float64, len(seq2))
+		scores[i] = make([]int, len(seq2))
+		for j, s2 := range seq2 {
+			ratios[i][j] = 1
+			if s1 != s2 {
+				ratios[i][j] = floatRatio([]rune(s1), []rune(s2))
+			}
+			scores[i][j] = int(round(scale * ratios[i][j]))
+		}
+	}
+	total := 0.0
+	for i, j := range maxAssignment(scores) {
+		if j >= 0 {
+			total += ratios[i][j]
+		}
+	}
+	return similarityScore(2 * total / float64(lenSum))
+}
+
+// seqDistance computes the generalized Levenshtein distance of two
+// sequences of strings with the costs of SeqRatio.
+func seqDistance(seq1, seq2 []string) float64 {
+	row := make([]float64, len(seq2)+1)
+	for j := range row {
+		row[j] = float64(j)
+	}
+	chrs2 := make([][]rune, len(seq2))
+	for j, s2 := range seq2 {
+		chrs2[j] = []rune(s2)
+	}
+	for i, s1 := range seq1 {
+		chrs1 := []rune(s1)
+		diag := row[0]
+		row[0] = float64(i + 1)
+		for j := range seq2 {
+			substitution := diag
+			if s1 != seq2[j] {
+				substitution += 2 * (1 - floatRatio(chrs1, chrs2[j]))
+			}
+			diag = row[j+1]
+			row[j+1] = math.Min(substitution, math.Min(row[j], row[j+1])+1)
+		}
+	}
+	return row[len(seq2)]
+}
@@ -0,0 +1,52 @@
+package fuzzy
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSeqRatio(t *testing.T) {
+	setlist := []string{"Intro", "Paranoid Android", "Karma Police", "Creep"}
+	cases := []struct {
+		seq1, seq2 []string
+		expected   int
+	}{
+		{setlist, setlist, 100},
+		{setlist, []string{"Intro", "Paranoid Android (live)", "Karma Police", "Creep"}, 96},
+		{setlist, []string{"Intro", "Karma Police", "Paranoid Android", "Creep"}, 75},
+		{setlist, []string{"Intro", "Karma Police", "Creep"}, 86},
+		{setlist, []string{"Airbag", "Lucky"}, 10},
+		{[]string{""}, []string{""}, 100},
+		{setlist, []string{}, 0},
+		{[]string{}, []string{}, 0},
+	}
+	for _, c := range cases {
+		assertRatio(t, "SeqRatio", fmt.Sprint(c.seq1), fmt.Sprint(c.seq2), c.expected, SeqRatio(c.seq1, c.seq2))
+		assertRatio(t, "SeqRatio", fmt.Sprint(c.seq2), fmt.Sprint(c.seq1), c.expected, SeqRatio(c.seq2, c.seq1))
+	}
+
+	// with distinct single-character elements, SeqRatio is Ratio
+	assertRatio(t, "SeqRatio", "kitten", "sitting", Ratio("kitten", "sitting"),
+		SeqRatio([]string{"k", "i", "t", "t", "e", "n"}, []string{"s", "i", "t", "t", "i", "n", "g"}))
+}
+
+func TestSetRatio(t *testing.T) {
+	setlist := []string{"Intro", "Paranoid Android", "Karma Police", "Creep"}
+	cases := []struct {
+		seq1, seq2 []string
+		expected   int
+	}{
+		{setlist, setlist, 100},
+		{setlist, []string{"Creep", "Karma Police", "Paranoid Android", "Intro"}, 100},
+		{setlist, []string{"Creep", "Karma Police", "Paranoid Android (live)", "Intro"}, 96},
+		{setlist, []string{"Intro", "Karma Police", "Creep"}, 86},
+		{setlist, []string{"Airbag", "Lucky"}, 10},
+		{[]string{""}, []string{""}, 100},
+		{setlist, []string{}, 0},
+		{[]string{}, []string{}, 0},
+	}
+	for _, c := range cases {
+		assertRatio(t, "SetRatio", fmt.Sprint(c.seq1), fmt.Sprint(c.seq2), c.expected, SetRatio(c.seq1, c.seq2))
+		assertRatio(t, "SetRatio", fmt.Sprint(c.seq2), fmt.Sprint(c.seq1), c.expected, SetRatio(c.seq2, c.seq1))
+	}
+}
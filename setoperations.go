@@ -1,5 +1,7 @@
 package fuzzy
 
+import "math"
+
 type StringSet struct {
 	elements map[string]bool
 }
@@ -65,3 +67,101 @@ func (s *StringSet) ToSlice() []string {
 	}
 	return keys
 }
+
+// Add adds strings to the set
+func (s *StringSet) Add(strs ...string) {
+	for _, str := range strs {
+		s.elements[str] = true
+	}
+}
+
+// Contains returns true if the string is in the set
+func (s *StringSet) Contains(str string) bool {
+	return s.elements[str]
+}
+
+// Len returns the number of strings in the set
+func (s *StringSet) Len() int {
+	return len(s.elements)
+}
+
+// Union returns the set of strings that are contained in
+// either set
+func (s *StringSet) Union(other *StringSet) *StringSet {
+	union := new(StringSet)
+	union.elements = make(map[string]bool, len(s.elements)+len(other.elements))
+	for k, v := range s.elements {
+		union.elements[k] = v
+	}
+	for k, v := range other.elements {
+		union.elements[k] = v
+	}
+	return union
+}
+
+// intersectionLen counts the strings contained in both sets
+func (s *StringSet) intersectionLen(other *StringSet) int {
+	if len(s.elements) > len(other.elements) {
+		s, other = other, s
+	}
+	n := 0
+	for k := range s.elements {
+		if other.elements[k] {
+			n++
+		}
+	}
+	return n
+}
+
+// Jaccard returns the Jaccard index of two sets, the size of their
+// intersection divided by the size of their union
+func (s *StringSet) Jaccard(other *StringSet) float64 {
+	shared := s.intersectionLen(other)
+	union := s.Len() + other.Len() - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+// Dice returns the Sørensen-Dice coefficient of two sets, twice the
+// size of their intersection divided by the sum of their sizes
+func (s *StringSet) Dice(other *StringSet) float64 {
+	total := s.Len() + other.Len()
+	if total == 0 {
+		return 0
+	}
+	return 2 * float64(s.intersectionLen(other)) / float64(total)
+}
+
+// Tversky returns the Tversky index of two sets, which weights the
+// strings only in this set by alpha and those only in the other set
+// by beta. With alpha and beta of 1 it equals the Jaccard index, and
+// with alpha and beta of 0.5 the Sørensen-Dice coefficient.
+func (s *StringSet) Tversky(other *StringSet, alpha, beta float64) float64 {
+	shared := float64(s.intersectionLen(other))
+	denominator := shared + alpha*(float64(s.Len())-shared) + beta*(float64(other.Len())-shared)
+	if denominator == 0 {
+		return 0
+	}
+	return shared / denominator
+}
+
+// Overlap returns the overlap coefficient of two sets, the size of
+// their intersection divided by the size of the smaller set
+func (s *StringSet) Overlap(other *StringSet) float64 {
+	smaller := min(s.Len(), other.Len())
+	if smaller == 0 {
+		return 0
+	}
+	return float64(s.intersectionLen(other)) / float64(smaller)
+}
+
+// Cosine returns the cosine similarity of two sets, the size of their
+// intersection divided by the geometric mean of their sizes
+func (s *StringSet) Cosine(other *StringSet) float64 {
+	if s.Len() == 0 || other.Len() == 0 {
+		return 0
+	}
+	return float64(s.intersectionLen(other)) / math.Sqrt(float64(s.Len())*float64(other.Len()))
+}
@@ -1,6 +1,7 @@
 package fuzzy
 
 import (
+	"math"
 	"testing"
 )
 
@@ -46,3 +47,45 @@ func TestIntersection(t *testing.T) {
 		t.Fatal()
 	}
 }
+
+func TestAddContainsUnion(t *testing.T) {
+	s1 := NewStringSet([]string{"ab"})
+	s1.Add("bc", "cd", "bc")
+	if s1.Len() != 3 || !s1.Contains("bc") || s1.Contains("de") {
+		t.Fatal()
+	}
+
+	s2 := NewStringSet([]string{"cd", "de"})
+	expectedUnion := NewStringSet([]string{"ab", "bc", "cd", "de"})
+	if !s1.Union(s2).Equals(expectedUnion) {
+		t.Fatal()
+	}
+}
+
+var setSimilarityTestData = []struct {
+	name     string
+	sim      func(s1, s2 *StringSet) float64
+	expected float64
+}{
+	{"Jaccard", (*StringSet).Jaccard, 0.5},
+	{"Dice", (*StringSet).Dice, 2.0 / 3},
+	{"Overlap", (*StringSet).Overlap, 2.0 / 3},
+	{"Cosine", (*StringSet).Cosine, 2.0 / 3},
+	{"Tversky(1,1)", func(s1, s2 *StringSet) float64 { return s1.Tversky(s2, 1, 1) }, 0.5},
+	{"Tversky(0.5,0.5)", func(s1, s2 *StringSet) float64 { return s1.Tversky(s2, 0.5, 0.5) }, 2.0 / 3},
+	{"Tversky(1,0)", func(s1, s2 *StringSet) float64 { return s1.Tversky(s2, 1, 0) }, 2.0 / 3},
+}
+
+func TestSetSimilarity(t *testing.T) {
+	s1 := NewStringSet([]string{"ab", "bc", "cd"})
+	s2 := NewStringSet([]string{"bc", "cd", "de"})
+	empty := NewStringSet(nil)
+	for _, testCase := range setSimilarityTestData {
+		if actual := testCase.sim(s1, s2); math.Abs(actual-testCase.expected) > 1e-9 {
+			t.Errorf("%v: Expected %v, got %v.", testCase.name, testCase.expected, actual)
+		}
+		if actual := testCase.sim(empty, empty); actual != 0 {
+			t.Errorf("%v of empty sets: Expected 0, got %v.", testCase.name, actual)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package fuzzy
+
+import "strings"
+
+// SetSimilarity measures the similarity of two sets in [0,1].
+type SetSimilarity func(a, b *StringSet) float64
+
+// The set similarity measures of StringSet.
+var (
+	Jaccard SetSimilarity = (*StringSet).Jaccard
+	Dice    SetSimilarity = (*StringSet).Dice
+	Overlap SetSimilarity = (*StringSet).Overlap
+	Cosine  SetSimilarity = (*StringSet).Cosine
+)
+
+// Tversky returns the Tversky index with the given weights
+// as a SetSimilarity (see StringSet.Tversky).
+func Tversky(alpha, beta float64) SetSimilarity {
+	return func(a, b *StringSet) float64 {
+		return a.Tversky(b, alpha, beta)
+	}
+}
+
+// JaccardRatio computes the Jaccard index of the sets of cleansed
+// whitespace-separated tokens of two strings, as a score [0,100].
+func JaccardRatio(s1, s2 string) int {
+	return setRatio(s1, s2, Jaccard, tokenSet)
+}
+
+// DiceRatio computes the Sørensen-Dice coefficient of the sets of cleansed
+// whitespace-separated tokens of two strings, as a score [0,100].
+func DiceRatio(s1, s2 string) int {
+	return setRatio(s1, s2, Dice, tokenSet)
+}
+
+// OverlapRatio computes the overlap coefficient of the sets of cleansed
+// whitespace-separated tokens of two strings, as a score [0,100].
+// It is 100 whenever the tokens of one string are a subset of the other's.
+func OverlapRatio(s1, s2 string) int {
+	return setRatio(s1, s2, Overlap, tokenSet)
+}
+
+// CosineRatio computes the cosine similarity of the sets of cleansed
+// whitespace-separated tokens of two strings, as a score [0,100].
+func CosineRatio(s1, s2 string) int {
+	return setRatio(s1, s2, Cosine, tokenSet)
+}
+
+// TverskyScorer returns a scorer computing the Tversky index of the
+// sets of cleansed whitespace-separated tokens of two strings, as a
+// score [0,100]. An alpha higher than beta favors choices that contain
+// all the tokens of the query when the scorer is passed to Extract.
+func TverskyScorer(alpha, beta float64) func(string, string) int {
+	return TokenSetScorer(Tversky(alpha, beta), nil)
+}
+
+// TokenSetScorer returns a scorer comparing the token sets of two strings
+// with the given similarity, as a score [0,100]. Tokens are cleansed
+// whatever opts says; its other options, such as a Tokenizer, Synonyms
+// and Stopwords, apply as for TokenSetRatio, and nil splits on whitespace.
+func TokenSetScorer(similarity SetSimilarity, opts *TokenOptions) func(string, string) int {
+	o := opts.cleansed()
+	return func(s1, s2 string) int {
+		return setRatio(s1, s2, similarity, func(s string) *StringSet {
+			return NewStringSet(o.tokenize(s))
+		})
+	}
+}
+
+// QGramScorer returns a scorer comparing the sets of q-grams, the
+// substrings of q runes, of two cleansed strings with the given
+// similarity, as a score [0,100]. Q-grams are robust to typos and
+// word boundaries: "jonsmith" and "john smith" share most of their
+// bigrams although they have no token in common.
+func QGramScorer(similarity SetSimilarity, q int) func(string, string) int {
+	return func(s1, s2 string) int {
+		return setRatio(s1, s2, similarity, func(s string) *StringSet {
+			return NewStringSet(nGrams(CollapseWhitespace(Cleanse(s, false)), q))
+		})
+	}
+}
+
+func tokenSet(s string) *StringSet {
+	return NewStringSet(strings.Fields(Cleanse(s, false)))
+}
+
+func setRatio(s1, s2 string, similarity SetSimilarity, toSet func(string) *StringSet) int {
+	return similarityScore(similarity(toSet(s1), toSet(s2)))
+}
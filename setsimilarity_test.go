@@ -0,0 +1,58 @@
+package fuzzy
+
+import (
+	"testing"
+)
+
+func TestTokenSetSimilarityRatios(t *testing.T) {
+	s1, s2 := "New York Mets", "New York Yankees"
+	assertRatio(t, "JaccardRatio", s1, s2, 50, JaccardRatio(s1, s2))
+	assertRatio(t, "DiceRatio", s1, s2, 67, DiceRatio(s1, s2))
+	assertRatio(t, "OverlapRatio", s1, s2, 67, OverlapRatio(s1, s2))
+	assertRatio(t, "CosineRatio", s1, s2, 67, CosineRatio(s1, s2))
+	assertRatio(t, "TverskyScorer", s1, s2, 50, TverskyScorer(1, 1)(s1, s2))
+
+	assertRatioIs100(t, "JaccardRatio", "Mets, New York", "new york mets", JaccardRatio("Mets, New York", "new york mets"))
+	assertRatioIs100(t, "OverlapRatio", "York", "New York Mets", OverlapRatio("York", "New York Mets"))
+	assertRatio(t, "JaccardRatio", "", "", 0, JaccardRatio("", ""))
+
+	// the query's missing tokens weigh more than the choice's extra ones
+	query, choice := "new york", "new york mets"
+	containsQuery := TverskyScorer(0.9, 0.1)
+	if containsQuery(query, choice) <= containsQuery(choice, query) {
+		t.Error("Expected an asymmetric Tversky index")
+	}
+}
+
+func TestTokenSetScorer(t *testing.T) {
+	scorer := TokenSetScorer(Jaccard, &TokenOptions{Stopwords: NewStopwords("the")})
+	assertRatioIs100(t, "TokenSetScorer", "The Beatles", "beatles", scorer("The Beatles", "beatles"))
+}
+
+func TestQGramScorer(t *testing.T) {
+	bigramDice := QGramScorer(Dice, 2)
+	assertRatio(t, "QGramScorer", "jonsmith", "john smith", 0, JaccardRatio("jonsmith", "john smith"))
+	if score := bigramDice("jonsmith", "john smith"); score < 60 {
+		t.Errorf("Expected jonsmith and john smith to share most bigrams, got %v", score)
+	}
+	assertRatio(t, "QGramScorer", "night", "nacht", 25, bigramDice("night", "nacht"))
+	assertRatioIs100(t, "QGramScorer", "ab", "AB", QGramScorer(Jaccard, 3)("ab", "AB"))
+}
+
+func TestSetSimilarityScorersWithProcess(t *testing.T) {
+	choices := []string{"new york mets", "new york yankees", "atlanta braves"}
+	match, err := ExtractOne("mets", choices, OverlapRatio)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertMatch(t, "mets", "new york mets", match.Match)
+
+	withDupes := []string{"Frodo Baggins", "Baggins, Frodo", "Tom Sawyer"}
+	res, err := Dedupe(withDupes, 70, JaccardRatio)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 2 {
+		t.Errorf("Expected Dedupe with JaccardRatio to leave 2 strings, got %v", res)
+	}
+}
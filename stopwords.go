@@ -0,0 +1,215 @@
+package fuzzy
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Stopwords is a set of filler words, such as "the" or "vs", that carry
+// little meaning when strings are compared. A *Stopwords can be set in
+// TokenOptions to be removed by the token scorers, and its Remove method
+// can be used as a processor or as a Pipeline stage. Words are matched
+// case-insensitively.
+type Stopwords struct {
+	set *StringSet
+}
+
+// NewStopwords creates a stopword set from the given words.
+func NewStopwords(words ...string) *Stopwords {
+	lowered := make([]string, len(words))
+	for i, w := range words {
+		lowered[i] = strings.ToLower(w)
+	}
+	return &Stopwords{set: NewStringSet(lowered)}
+}
+
+// ReadStopwords reads a stopword set from r. Words are separated by
+// whitespace, and lines starting with # are ignored.
+func ReadStopwords(r io.Reader) (*Stopwords, error) {
+	words := []string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, strings.Fields(line)...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return NewStopwords(words...), nil
+}
+
+// LanguageStopwords returns the embedded stopword list for a language,
+// identified by its ISO 639-1 code: da, de, en, es, fi, fr, it, nl, no,
+// pt, ru or sv.
+func LanguageStopwords(lang string) (*Stopwords, error) {
+	list, ok := stopwordLists[strings.ToLower(lang)]
+	if !ok {
+		return nil, fmt.Errorf("no stopword list for language %q", lang)
+	}
+	return NewStopwords(strings.Fields(list)...), nil
+}
+
+// Union returns a stopword set containing the words of both sets.
+func (sw *Stopwords) Union(other *Stopwords) *Stopwords {
+	return NewStopwords(append(sw.set.ToSlice(), other.set.ToSlice()...)...)
+}
+
+// Contains reports whether word is a stopword.
+func (sw *Stopwords) Contains(word string) bool {
+	return sw.set.elements[strings.ToLower(word)]
+}
+
+// Remove removes stopwords from the whitespace-separated tokens of s and
+// joins the remaining tokens with single spaces. If every token is a
+// stopword, all tokens are kept, so that names such as "The The"
+// remain comparable.
+func (sw *Stopwords) Remove(s string) string {
+	return strings.Join(sw.filter(strings.Fields(s)), " ")
+}
+
+// filter drops stopwords from tokens, unless that would leave no tokens.
+func (sw *Stopwords) filter(tokens []string) []string {
+	kept := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if !sw.Contains(token) {
+			kept = append(kept, token)
+		}
+	}
+	if len(kept) == 0 {
+		return tokens
+	}
+	return kept
+}
+
+var stopwordLists = map[string]string{
+	"da": `af alle andet andre at begge da de den denne der deres det dette dig din
+		dog du ej eller en end ene eneste enhver et fem fire flere fleste for fordi
+		forrige fra få før god han hans har hendes her hun hvad hvem hver hvilken
+		hvis hvor hvordan hvorfor hvornår i ikke ind ingen intet jeg jeres kan kom
+		kommer lav lidt lille man mand mange med meget men mens mere mig ned ni nogen
+		noget ny nyt nær næste næsten og op otte over på se seks ses som stor store
+		syv ti til to tre ud var`,
+	"de": `aber alle allem allen aller alles als also am an ander andere anderem
+		anderen anderer anderes anderm andern anderr anders auch auf aus bei bin bis
+		bist da damit dann das dass dasselbe dazu daß dein deine deinem deinen deiner
+		deines dem demselben den denn denselben der derer derselbe derselben des
+		desselben dessen dich die dies diese dieselbe dieselben diesem diesen dieser
+		dieses dir doch dort du durch ein eine einem einen einer eines einig einige
+		einigem einigen einiger einiges einmal er es etwas euch euer eure eurem euren
+		eurer eures für gegen gewesen hab habe haben hat hatte hatten hier hin hinter
+		ich ihm ihn ihnen ihr ihre ihrem ihren ihrer ihres im in indem ins ist jede
+		jedem jeden jeder jedes jene jenem jenen jener jenes jetzt kann kein keine
+		keinem keinen keiner keines können könnte machen man manche manchem manchen
+		mancher manches mein meine meinem meinen meiner meines mich mir mit muss
+		musste nach nicht nichts noch nun nur ob oder ohne sehr sein seine seinem
+		seinen seiner seines selbst sich sie sind so solche solchem solchen solcher
+		solches soll sollte sondern sonst um und uns unsere unserem unseren unser
+		unseres unter viel vom von vor war waren warst was weg weil weiter welche
+		welchem welchen welcher welches wenn werde werden wie wieder will wir wird
+		wirst wo wollen wollte während würde würden zu zum zur zwar zwischen über`,
+	"en": `a about above after again against all am an and any are as at be
+		because been before being below between both but by can could did do does
+		doing down during each few for from further had has have having he her here
+		hers herself him himself his how i if in into is it its itself just me more
+		most my myself no nor not of off on once only or other our ours ourselves out
+		over own same she should so some such than that the their theirs them
+		themselves then there these they this those through to too under until up
+		very was we were what when where which while who whom why will with would you
+		your yours yourself yourselves vs versus`,
+	"es": `a al algo algunas algunos ante antes como con contra cual cuando de del
+		desde donde durante e el ella ellas ellos en entre era erais eran eras eres
+		es esa esas ese eso esos esta estaba estado estamos estar estas este esto
+		estos estoy fue fueron fui ha había han has hasta hay la las le les lo los
+		me mi mis mucho muchos muy más mí nada ni no nos nosotras nosotros nuestra
+		nuestras nuestro nuestros o os otra otras otro otros para pero poco por
+		porque que quien quienes qué se sea ser si sido sin sobre sois somos son soy
+		su sus suya suyas suyo suyos también tanto te tenemos tener tengo ti tiene
+		tienen todo todos tu tus tuya tuyas tuyo tuyos tú un una uno unos vosotras
+		vosotros vuestra vuestras vuestro vuestros y ya yo él`,
+	"fi": `ei eivät emme en et ette että he heidän heidät heihin heille heillä
+		heiltä heissä heistä heitä hän häneen hänelle hänellä häneltä hänen hänessä
+		hänestä hänet häntä itse ja johon joiden joihin joiksi joilla joille joilta
+		joina joissa joista joita joka joksi jolla jolle jolta jona jonka jos jossa
+		josta jota jotka kanssa keiden keihin keiksi keille keillä keiltä keinä
+		keissä keistä keitä keneen keneksi kenelle kenellä keneltä kenen kenenä
+		kenessä kenestä kenet ketkä ketä koska kuin kuka kun me meidän meidät meihin
+		meille meillä meiltä meissä meistä meitä mikä minä minua minulla minulle
+		minulta minun minussa minusta minut minuun mitkä mitä mukaan mutta ne niiden
+		niihin niiksi niille niillä niiltä niin niinä niissä niistä niitä noiden
+		noihin noiksi noilla noille noilta noin noina noissa noista noita nuo nyt
+		näiden näihin näiksi näille näillä näiltä näinä näissä näistä näitä nämä ole
+		olemme olen olet olette oli olimme olin olisi olisimme olisin olisit
+		olisitte olisivat olit olitte olivat olla olleet ollut on ovat poikki se
+		sekä sen siihen siinä siitä siksi sille sillä sillä siltä sinua sinulla
+		sinulle sinulta sinun sinussa sinusta sinut sinuun sinä sitä tai te teidän
+		teidät teihin teille teillä teiltä teissä teistä teitä tuo tuohon tuoksi
+		tuolla tuolle tuolta tuon tuona tuossa tuosta tuota tähän täksi tälle tällä
+		tältä tämä tämän tänä tässä tästä tätä vaan vai vaikka yli`,
+	"fr": `a au aux avec ce ces dans de des du elle en et eux il ils je la le les
+		leur lui ma mais me même mes moi mon ne nos notre nous on ou par pas pour
+		qu que qui sa se ses son sur ta te tes toi ton tu un une vos votre vous c d
+		j l m n s t y été étée étées étés étant suis es est sommes êtes sont serai
+		seras sera serons serez seront serais serait serions seriez seraient étais
+		était étions étiez étaient fus fut fûmes fûtes furent sois soit soyons soyez
+		soient fusse fusses fût fussions fussiez fussent ayant eu eue eues eus ai as
+		avons avez ont aurai auras aura aurons aurez auront aurais aurait aurions
+		auriez auraient avais avait avions aviez avaient eut eûmes eûtes eurent aie
+		aies ait ayons ayez aient eusse eusses eût eussions eussiez eussent contre`,
+	"it": `ad al allo ai agli all agl alla alle con col coi da dal dallo dai dagli
+		dall dagl dalla dalle di del dello dei degli dell degl della delle in nel
+		nello nei negli nell negl nella nelle su sul sullo sui sugli sull sugl sulla
+		sulle per tra contro io tu lui lei noi voi loro mio mia miei mie tuo tua tuoi
+		tue suo sua suoi sue nostro nostra nostri nostre vostro vostra vostri vostre
+		mi ti ci vi lo la li le gli ne il un uno una ma ed se perché anche come dov
+		dove che chi cui non più quale quanto quanti quanta quante quello quelli
+		quella quelle questo questi questa queste si tutto tutti a c e i l o ho hai
+		ha abbiamo avete hanno è sono sei siamo siete era erano fu furono`,
+	"nl": `aan al alles als altijd andere ben bij daar dan dat de der deze die dit
+		doch doen door dus een eens en er ge geen geweest haar had heb hebben heeft
+		hem het hier hij hoe hun iemand iets ik in is ja je kan kon kunnen maar me
+		meer men met mij mijn moet na naar niet niets nog nu of om omdat onder ons
+		ook op over reeds te tegen toch toen tot u uit uw van veel voor want waren
+		was wat werd wezen wie wil worden wordt zal ze zelf zich zij zijn zo zonder
+		zou`,
+	"no": `alle at av bare begge ble blei bli blir blitt både båe da de deg dei deim
+		deira deires dem den denne der dere deres det dette di din disse ditt du dykk
+		dykkar då eg ein eit eitt eller elles en enn er et ett etter for fordi fra
+		før ha hadde han hans har hennar henne hennes her hjå ho hoe honom hoss
+		hossen hun hva hvem hver hvilke hvilken hvis hvor hvordan hvorfor i ikke
+		ikkje ingen ingi inkje inn inni ja jeg kan kom korleis korso kun kunne kva
+		kvar kvarhelst kven kvi kvifor man mange me med medan meg meget mellom men mi
+		min mine mitt mot mykje ned no noe noen noka noko nokon nokor nokre nå når og
+		også om opp oss over på samme seg selv si sia sidan siden sin sine sitt sjøl
+		skal skulle slik so som somme somt så sånn til um upp ut uten var vart varte
+		ved vere verte vi vil ville vore vors vort vår være vært å`,
+	"pt": `a ao aos aquela aquelas aquele aqueles aquilo as até com como da das de
+		dela delas dele deles depois do dos e ela elas ele eles em entre era eram
+		essa essas esse esses esta estas este estes eu foi fomos for foram fosse
+		fossem fui há isso isto já lhe lhes mais mas me mesmo meu meus minha minhas
+		muito na nas nem no nos nossa nossas nosso nossos num numa não nós o os ou
+		para pela pelas pelo pelos por qual quando que quem se sem ser seu seus só
+		sua suas também te tem teu teus tu tua tuas um uma você vocês vos à às é`,
+	"ru": `и в во не что он на я с со как а то все она так его но да ты к у же вы
+		за бы по только ее мне было вот от меня еще нет о из ему теперь когда даже
+		ну вдруг ли если уже или ни быть был него до вас нибудь опять уж вам ведь
+		там потом себя ничего ей может они тут где есть надо ней для мы тебя их чем
+		была сам чтоб без будто чего раз тоже себе под будет ж тогда кто этот того
+		потому этого какой совсем ним здесь этом один почти мой тем чтобы нее сейчас
+		были куда зачем всех никогда можно при наконец два об другой хоть после над
+		больше тот через эти нас про всего них какая много разве три эту моя
+		впрочем хорошо свою этой перед иногда лучше чуть том нельзя такой им более
+		всегда конечно всю между`,
+	"sv": `alla allt att av blev bli blir blivit de dem den denna deras dess dessa
+		det detta dig din dina ditt du där då efter ej eller en er era ert ett från
+		för ha hade han hans har henne hennes hon honom hur här i icke ingen inom
+		inte jag ju kan kunde man med mellan men mig min mina mitt mot mycket ni nu
+		när någon något några och om oss på samma sedan sig sin sina sitta själv
+		skulle som så sådan sådana sådant till under upp ut utan vad var vara varför
+		varit varje vars vart vem vi vid vilka vilkas vilken vilket vår våra vårt
+		än är åt över`,
+}
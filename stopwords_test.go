@@ -0,0 +1,78 @@
+package fuzzy
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLanguageStopwords(t *testing.T) {
+	for _, lang := range []string{"da", "de", "en", "es", "fi", "fr", "it", "nl", "no", "pt", "ru", "sv"} {
+		if _, err := LanguageStopwords(lang); err != nil {
+			t.Errorf("Expected stopword list for %v. Got %v", lang, err)
+		}
+	}
+	if _, err := LanguageStopwords("xx"); err == nil {
+		t.Error("Expected an error for an unknown language")
+	}
+
+	en, _ := LanguageStopwords("EN")
+	if !en.Contains("The") || en.Contains("stones") {
+		t.Error("Expected English stopwords to contain 'the' but not 'stones'")
+	}
+}
+
+func TestStopwordsRemove(t *testing.T) {
+	sw := NewStopwords("the", "vs", "at")
+	removeData := [][]string{
+		{"The Rolling  Stones", "Rolling Stones"},
+		{"new york mets vs chicago cubs", "new york mets chicago cubs"},
+		{"The The", "The The"},
+		{"", ""},
+	}
+	for _, testCase := range removeData {
+		if actual := sw.Remove(testCase[0]); actual != testCase[1] {
+			t.Errorf("Remove %v: Expected %v, got %v.", testCase[0], testCase[1], actual)
+		}
+	}
+}
+
+func TestReadStopwords(t *testing.T) {
+	sw, err := ReadStopwords(strings.NewReader("# sports\nvs versus\n  at\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range []string{"vs", "versus", "at"} {
+		if !sw.Contains(w) {
+			t.Errorf("Expected stopwords to contain %v", w)
+		}
+	}
+	if sw.Contains("#") || sw.Contains("sports") {
+		t.Error("Expected comment lines to be ignored")
+	}
+
+	union := sw.Union(NewStopwords("the"))
+	if !union.Contains("the") || !union.Contains("vs") {
+		t.Error("Expected union to contain words of both sets")
+	}
+}
+
+func TestStopwordsOption(t *testing.T) {
+	en, _ := LanguageStopwords("en")
+
+	s1, s2 := "The Rolling Stones", "Rolling Stones"
+	assertRatioIsNot100(t, "TokenSortRatio", s1, s2, TokenSortRatio(s1, s2, false, true))
+	assertRatioIs100(t, "TokenSortRatio", s1, s2, (&TokenOptions{Cleanse: true, Stopwords: en}).TokenSortRatio(s1, s2))
+
+	s3, s4 := "new york mets vs chicago cubs", "chicago cubs at new york mets"
+	assertRatioIsNot100(t, "TokenSetRatio", s3, s4, TokenSetRatio(s3, s4))
+	assertRatioIs100(t, "TokenSetRatio", s3, s4, (&TokenOptions{Stopwords: en}).TokenSetRatio(s3, s4))
+
+	// filler words alone do not make short strings match
+	s5, s6 := "the strokes", "the band"
+	if r1, r2 := TokenSetRatio(s5, s6), (&TokenOptions{Stopwords: en}).TokenSetRatio(s5, s6); r2 >= r1 {
+		t.Errorf("Expected TokenSetRatio of %v and %v to drop without stopwords. Got %v and %v", s5, s6, r1, r2)
+	}
+
+	s7, s8 := "The The", "the the"
+	assertRatioIs100(t, "TokenSetRatio", s7, s8, (&TokenOptions{Cleanse: true, Stopwords: en}).TokenSetRatio(s7, s8))
+}
@@ -5,19 +5,34 @@ import (
 	"unicode"
 )
 
+// Cleanse trims s, replaces every rune that is not a letter or number
+// with a space, and lowercases the result. If forceASCII is true,
+// non-ASCII characters are removed first; transliterate s beforehand
+// (see Transliterate) to keep them as ASCII letters, so that "Zürich"
+// becomes "zurich" rather than "zrich".
 func Cleanse(s string, forceASCII bool) string {
 	if forceASCII {
 		s = ASCIIOnly(s)
 	}
 	s = strings.TrimSpace(s)
-	rs := make([]rune, 0, len(s))
-	for _, r := range s {
+	return strings.ToLower(StripPunctuation(s))
+}
+
+// StripPunctuation replaces every rune in s that is not a letter
+// or number with a space.
+func StripPunctuation(s string) string {
+	return strings.Map(func(r rune) rune {
 		if !unicode.IsLetter(r) && !unicode.IsNumber(r) {
-			r = ' '
+			return ' '
 		}
-		rs = append(rs, r)
-	}
-	return strings.ToLower(string(rs))
+		return r
+	}, s)
+}
+
+// CollapseWhitespace collapses runs of whitespace in s into
+// single spaces and removes leading and trailing whitespace.
+func CollapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
 }
 
 func ASCIIOnly(s string) string {
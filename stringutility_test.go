@@ -19,6 +19,12 @@ var cleanseData = [][]interface{}{
 	{"ABC123", "abc123", "abc123"},
 }
 
+var cleanseTransliterateData = [][]interface{}{
+	{"  Zürich ", "zurich"},
+	{"Beyoncé!", "beyonce "},
+	{"ǩƱ©", "k"},
+}
+
 func TestASCIIOnly(t *testing.T) {
 	for _, testCase := range asciiOnlyData {
 		actual := ASCIIOnly(testCase[0].(string))
@@ -50,3 +56,14 @@ func TestCleanse(t *testing.T) {
 		}
 	}
 }
+
+func TestCleanseTransliterate(t *testing.T) {
+	for _, testCase := range cleanseTransliterateData {
+		actual := Cleanse(Transliterate(testCase[0].(string)), true)
+		expected := testCase[1]
+		if actual != expected {
+			t.Errorf("Cleanse %v: Expected %v, got %v.",
+				testCase[0], expected, actual)
+		}
+	}
+}
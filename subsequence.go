@@ -0,0 +1,206 @@
+package fuzzy
+
+import "unicode"
+
+// SubsequenceMatch is a match of a pattern as a subsequence of a string:
+// its score and the positions, in runes, of the matched characters.
+type SubsequenceMatch struct {
+	Score     int
+	Positions []int
+}
+
+// scores of the subsequence matcher, those of fzf
+const (
+	subseqScoreMatch        = 16
+	subseqGapStart          = -3
+	subseqGapExtension      = -1
+	subseqBonusBoundary     = subseqScoreMatch / 2
+	subseqBonusNonWord      = subseqScoreMatch / 2
+	subseqBonusCamel        = subseqBonusBoundary + subseqGapExtension
+	subseqBonusConsecutive  = -(subseqGapStart + subseqGapExtension)
+	subseqBonusWhite        = subseqBonusBoundary + 2
+	subseqBonusDelimiter    = subseqBonusBoundary + 1
+	subseqFirstCharMultiple = 2
+)
+
+type subseqCharClass int
+
+const (
+	subseqWhite subseqCharClass = iota
+	subseqNonWord
+	subseqDelimiter
+	subseqLower
+	subseqUpper
+	subseqLetter
+	subseqNumber
+)
+
+// MatchSubsequence matches pattern as a subsequence of s, the way fzf
+// and Sublime Text pickers do: every rune of the pattern must appear in
+// s in the same order, not necessarily next to each other. Of all the
+// ways to match, the one with the highest score is returned, where
+// matched runes score more at the start of words, after path separators
+// and at camelCase and letter-to-digit transitions, runs of consecutive
+// runes score a bonus, and gaps between matched runes are penalized.
+// Matching ignores case unless the pattern contains an uppercase letter.
+// Returns nil if pattern is not a subsequence of s.
+func MatchSubsequence(pattern, s string) *SubsequenceMatch {
+	return matchSubsequence([]rune(pattern), []rune(s))
+}
+
+// SubsequenceRatio computes a score of how well query matches s as a
+// subsequence (see MatchSubsequence), relative to the best score query
+// can get, so that it can be passed to Extract to rank file paths,
+// commands or identifiers:
+//
+//	matches, err := Extract("fzmain", paths, 10, SubsequenceRatio, func(s string) string { return s })
+//
+// The processor keeps paths as they are, as Cleanse would remove the
+// separators and case the score depends on.
+// Returns an integer score [0,100], and 0 if query is not a subsequence of s.
+func SubsequenceRatio(query, s string) int {
+	chrs := []rune(query)
+	m := matchSubsequence(chrs, []rune(s))
+	if m == nil {
+		return 0
+	}
+	best := matchSubsequence(chrs, chrs)
+	return similarityScore(float64(m.Score) / float64(best.Score))
+}
+
+func subseqClass(chr rune) subseqCharClass {
+	switch {
+	case unicode.IsLower(chr):
+		return subseqLower
+	case unicode.IsUpper(chr):
+		return subseqUpper
+	case unicode.IsNumber(chr):
+		return subseqNumber
+	case unicode.IsLetter(chr):
+		return subseqLetter
+	case unicode.IsSpace(chr):
+		return subseqWhite
+	case chr == '/' || chr == '\\' || chr == ',' || chr == ':' || chr == ';' || chr == '|':
+		return subseqDelimiter
+	}
+	return subseqNonWord
+}
+
+// subseqBonus returns the bonus of matching a rune of class after a
+// rune of class prev.
+func subseqBonus(prev, class subseqCharClass) int {
+	if class > subseqDelimiter {
+		switch prev {
+		case subseqWhite:
+			return subseqBonusWhite
+		case subseqDelimiter:
+			return subseqBonusDelimiter
+		case subseqNonWord:
+			return subseqBonusBoundary
+		}
+	}
+	if prev == subseqLower && class == subseqUpper || prev != subseqNumber && class == subseqNumber {
+		return subseqBonusCamel
+	}
+	switch class {
+	case subseqNonWord, subseqDelimiter:
+		return subseqBonusNonWord
+	case subseqWhite:
+		return subseqBonusWhite
+	}
+	return 0
+}
+
+func matchSubsequence(pattern, chrs []rune) *SubsequenceMatch {
+	m, n := len(pattern), len(chrs)
+	if m == 0 || m > n {
+		return nil
+	}
+	caseSensitive := false
+	for _, chr := range pattern {
+		if unicode.IsUpper(chr) {
+			caseSensitive = true
+		}
+	}
+	text := chrs
+	if !caseSensitive {
+		text = foldRunes(chrs)
+	}
+
+	bonus := make([]int, n)
+	prev := subseqWhite
+	for j, chr := range chrs {
+		class := subseqClass(chr)
+		bonus[j] = subseqBonus(prev, class)
+		prev = class
+	}
+
+	// match[i][j] is the best score of pattern[:i+1] with pattern[i]
+	// matched at j, or unset; from[i][j] is where pattern[i-1] was
+	// matched and runBonus[i][j] the bonus of the first rune of the
+	// run of consecutive matches ending at j
+	const unset = -1 << 30
+	match := make([][]int, m)
+	from := make([][]int, m)
+	runBonus := make([][]int, m)
+	for i := range match {
+		match[i] = make([]int, n)
+		from[i] = make([]int, n)
+		runBonus[i] = make([]int, n)
+		for j := range match[i] {
+			match[i][j] = unset
+		}
+	}
+	for i := 0; i < m; i++ {
+		// best score of pattern[:i] matched before j, with the gap to j
+		// penalized, and where pattern[i-1] was matched
+		gap, gapFrom := unset, -1
+		for j := i; j < n; j++ {
+			if i > 0 && j >= 2 && match[i-1][j-2] != unset {
+				if start := match[i-1][j-2] + subseqGapStart; start >= gap+subseqGapExtension {
+					gap, gapFrom = start, j-2
+				} else {
+					gap += subseqGapExtension
+				}
+			} else if gap != unset {
+				gap += subseqGapExtension
+			}
+			if text[j] != pattern[i] {
+				continue
+			}
+			if i == 0 {
+				match[i][j] = subseqScoreMatch + bonus[j]*subseqFirstCharMultiple
+				runBonus[i][j] = bonus[j]
+				continue
+			}
+			if prev := match[i-1][j-1]; prev != unset {
+				b, first := bonus[j], runBonus[i-1][j-1]
+				if b < subseqBonusBoundary || b <= first {
+					b = maxInt(b, subseqBonusConsecutive, first)
+				} else {
+					first = b
+				}
+				match[i][j], from[i][j], runBonus[i][j] = prev+subseqScoreMatch+b, j-1, first
+			}
+			if gap != unset && gap+subseqScoreMatch+bonus[j] > match[i][j] {
+				match[i][j], from[i][j], runBonus[i][j] = gap+subseqScoreMatch+bonus[j], gapFrom, bonus[j]
+			}
+		}
+	}
+
+	best, end := unset, -1
+	for j, score := range match[m-1] {
+		if score > best {
+			best, end = score, j
+		}
+	}
+	if end < 0 {
+		return nil
+	}
+	positions := make([]int, m)
+	for i := m - 1; i >= 0; i-- {
+		positions[i] = end
+		end = from[i][end]
+	}
+	return &SubsequenceMatch{Score: best, Positions: positions}
+}
@@ -0,0 +1,51 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchSubsequence(t *testing.T) {
+	cases := []struct {
+		pattern, s string
+		score      int
+		positions  []int
+	}{
+		{"abc", "abc", 88, []int{0, 1, 2}},
+		// path separators and camelCase transitions start words
+		{"fzmain", "src/fuzzy/main.go", 142, []int{4, 7, 10, 11, 12, 13}},
+		{"ur", "getUserRecord", 48, []int{3, 7}},
+		{"ur", "future", 36, []int{3, 4}},
+		{"gb", "go build", 58, []int{0, 3}},
+		// matching is case-sensitive if the pattern has uppercase letters
+		{"FB", "FooBar", 55, []int{0, 3}},
+	}
+	for _, c := range cases {
+		m := MatchSubsequence(c.pattern, c.s)
+		if m == nil || m.Score != c.score || !reflect.DeepEqual(m.Positions, c.positions) {
+			t.Errorf("MatchSubsequence(%q, %q): Expected %v %v, got %+v.", c.pattern, c.s, c.score, c.positions, m)
+		}
+	}
+
+	for _, c := range [][2]string{{"gb", "debug"}, {"FB", "fooBar"}, {"", "abc"}, {"abc", "ab"}} {
+		if m := MatchSubsequence(c[0], c[1]); m != nil {
+			t.Errorf("MatchSubsequence(%q, %q): Expected no match, got %+v.", c[0], c[1], m)
+		}
+	}
+}
+
+func TestSubsequenceRatio(t *testing.T) {
+	assertRatioIs100(t, "SubsequenceRatio", "main", "main", SubsequenceRatio("main", "main"))
+	assertRatio(t, "SubsequenceRatio", "ur", "getUserRecord", 77, SubsequenceRatio("ur", "getUserRecord"))
+	assertRatio(t, "SubsequenceRatio", "ur", "future", 58, SubsequenceRatio("ur", "future"))
+	assertRatio(t, "SubsequenceRatio", "gb", "debug", 0, SubsequenceRatio("gb", "debug"))
+	assertRatio(t, "SubsequenceRatio", "", "", 0, SubsequenceRatio("", ""))
+
+	paths := []string{"docs/gitbook.md", "cmd/git-branch/main.go", "internal/bugs.go"}
+	keep := func(s string) string { return s }
+	match, err := ExtractOne("gb", paths, SubsequenceRatio, keep)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertMatch(t, "gb", "cmd/git-branch/main.go", match.Match)
+}
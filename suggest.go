@@ -0,0 +1,171 @@
+package fuzzy
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SuggestOptions configures Suggest. A nil *SuggestOptions uses the
+// defaults.
+type SuggestOptions struct {
+	// Limit is the largest number of suggestions, 3 if zero or negative.
+	Limit int
+	// MaxDistance is the largest edit distance between the input and a
+	// suggestion. If zero, it depends on the length of the input: one
+	// edit up to 5 runes, then one more for every 3 runes, so that
+	// "colr" suggests "color" but "id" does not suggest "at".
+	MaxDistance int
+	// CaseSensitive compares the input and candidates without
+	// lowercasing them.
+	CaseSensitive bool
+}
+
+// Suggest returns the candidates that input is most likely a misspelling
+// of, best first, to suggest alternatives to an unknown command, flag or
+// configuration key. Candidates are ranked by Ratio and must be within
+// the edit distance allowed by opts; ties keep the order of candidates.
+// Returns an empty slice when nothing is close enough.
+func Suggest(input string, candidates []string, opts *SuggestOptions) []string {
+	o := SuggestOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Limit <= 0 {
+		o.Limit = 3
+	}
+	processor := func(s string) string {
+		s = strings.TrimSpace(s)
+		if !o.CaseSensitive {
+			s = strings.ToLower(s)
+		}
+		return s
+	}
+	input = processor(input)
+	if input == "" {
+		return []string{}
+	}
+	maxDistance := o.MaxDistance
+	if maxDistance == 0 {
+		maxDistance = suggestMaxDistance(input)
+	}
+
+	matches, err := ExtractWithoutOrder(input, candidates, Ratio, processor)
+	if err != nil {
+		return []string{}
+	}
+	suggestions := []string{}
+	seen := make(map[string]bool)
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	for _, match := range matches {
+		if len(suggestions) >= o.Limit {
+			break
+		}
+		if seen[match.Match] || EditDistance(input, processor(match.Match)) > maxDistance {
+			continue
+		}
+		seen[match.Match] = true
+		suggestions = append(suggestions, match.Match)
+	}
+	return suggestions
+}
+
+func suggestMaxDistance(input string) int {
+	n := len([]rune(input))
+	if n <= 5 {
+		return 1
+	}
+	return 1 + (n-3)/3
+}
+
+// FormatSuggestions appends suggestions to an error message as a
+// question, such as "unknown flag --colr, did you mean --color?".
+// The message is returned unchanged if there are no suggestions.
+func FormatSuggestions(message string, suggestions []string) string {
+	switch len(suggestions) {
+	case 0:
+		return message
+	case 1:
+		return message + ", did you mean " + suggestions[0] + "?"
+	}
+	last := len(suggestions) - 1
+	return message + ", did you mean " + strings.Join(suggestions[:last], ", ") + " or " + suggestions[last] + "?"
+}
+
+// SuggestionError reports an unknown input, such as a flag or a
+// configuration key, along with the candidates it may be a misspelling of.
+type SuggestionError struct {
+	Kind        string
+	Input       string
+	Suggestions []string
+}
+
+// NewSuggestionError returns an error reporting that input is an unknown
+// kind of name, such as "flag", with the suggestions of Suggest for it
+// among candidates, using the default options.
+func NewSuggestionError(kind, input string, candidates []string) *SuggestionError {
+	return &SuggestionError{Kind: kind, Input: input, Suggestions: Suggest(input, candidates, nil)}
+}
+
+// Error formats the error with FormatSuggestions:
+//
+//	unknown flag --colr, did you mean --color?
+func (e *SuggestionError) Error() string {
+	return FormatSuggestions("unknown "+e.Kind+" "+e.Input, e.Suggestions)
+}
+
+// StructKeys returns the keys of the fields of a struct, or of the struct
+// a pointer points to, for suggesting configuration keys with Suggest.
+// The key of a field is its name in the given struct tag, such as "json"
+// or "yaml", or the field name if the field has no such tag. Unexported
+// fields and fields tagged "-" are skipped, and the fields of embedded
+// structs without a tag name are included as if they were fields of v;
+// a struct embedded more than once, as in a recursive type, is included
+// only the first time. Returns nil if v is not a struct.
+func StructKeys(v interface{}, tag string) []string {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	return structKeys(t, tag, map[reflect.Type]bool{t: true})
+}
+
+// structKeys returns the keys of struct type t, skipping embedded structs
+// already visited.
+func structKeys(t reflect.Type, tag string, visited map[reflect.Type]bool) []string {
+	keys := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get(tag), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if !visited[embedded] {
+					visited[embedded] = true
+					keys = append(keys, structKeys(embedded, tag, visited)...)
+				}
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			// unexported
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		keys = append(keys, name)
+	}
+	return keys
+}
@@ -0,0 +1,108 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSuggest(t *testing.T) {
+	flags := []string{"--color", "--colour", "--column", "--verbose", "--version", "--help"}
+	cases := []struct {
+		input    string
+		opts     *SuggestOptions
+		expected []string
+	}{
+		{"--colr", nil, []string{"--color", "--colour"}},
+		{"--COLR", nil, []string{"--color", "--colour"}},
+		{"--COLR", &SuggestOptions{CaseSensitive: true}, []string{}},
+		{"--verison", nil, []string{"--version"}},
+		{"--colr", &SuggestOptions{Limit: 1}, []string{"--color"}},
+		{"--colr", &SuggestOptions{Limit: -1, MaxDistance: 10}, []string{"--color", "--colour", "--column"}},
+		{"--colr", &SuggestOptions{MaxDistance: 3}, []string{"--color", "--colour", "--column"}},
+		{"--output", nil, []string{}},
+		{"", nil, []string{}},
+	}
+	for _, c := range cases {
+		if actual := Suggest(c.input, flags, c.opts); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("Suggest(%q, %+v): Expected %v, got %v.", c.input, c.opts, c.expected, actual)
+		}
+	}
+
+	// the allowed distance grows with the input
+	if actual := Suggest("id", []string{"at", "ids"}, nil); !reflect.DeepEqual(actual, []string{"ids"}) {
+		t.Errorf("Suggest: Expected [ids], got %v.", actual)
+	}
+	if actual := Suggest("recieve_timout", []string{"receive_timeout"}, nil); !reflect.DeepEqual(actual, []string{"receive_timeout"}) {
+		t.Errorf("Suggest: Expected [receive_timeout], got %v.", actual)
+	}
+}
+
+func TestFormatSuggestions(t *testing.T) {
+	cases := []struct {
+		suggestions []string
+		expected    string
+	}{
+		{nil, "unknown flag --colr"},
+		{[]string{"--color"}, "unknown flag --colr, did you mean --color?"},
+		{[]string{"--color", "--colour"}, "unknown flag --colr, did you mean --color or --colour?"},
+		{[]string{"--color", "--colour", "--column"}, "unknown flag --colr, did you mean --color, --colour or --column?"},
+	}
+	for _, c := range cases {
+		if actual := FormatSuggestions("unknown flag --colr", c.suggestions); actual != c.expected {
+			t.Errorf("FormatSuggestions(%v): Expected %q, got %q.", c.suggestions, c.expected, actual)
+		}
+	}
+
+	err := NewSuggestionError("flag", "--colr", []string{"--color", "--help"})
+	if expected := "unknown flag --colr, did you mean --color?"; err.Error() != expected {
+		t.Errorf("SuggestionError: Expected %q, got %q.", expected, err.Error())
+	}
+}
+
+type suggestTestBase struct {
+	Name string `json:"name"`
+}
+
+type suggestTestConfig struct {
+	suggestTestBase
+	Timeout  int    `json:"timeout,omitempty"`
+	LogLevel string `json:"log_level"`
+	Secret   string `json:"-"`
+	Retries  int
+	internal bool
+}
+
+type suggestTestNode struct {
+	*suggestTestNode
+	*suggestTestEdge
+	Name string `json:"name"`
+}
+
+type suggestTestEdge struct {
+	*suggestTestNode
+	Weight int `json:"weight"`
+}
+
+func TestStructKeys(t *testing.T) {
+	expected := []string{"name", "timeout", "log_level", "Retries"}
+	if actual := StructKeys(&suggestTestConfig{}, "json"); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("StructKeys: Expected %v, got %v.", expected, actual)
+	}
+	expected = []string{"Name", "Timeout", "LogLevel", "Secret", "Retries"}
+	if actual := StructKeys(suggestTestConfig{}, "yaml"); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("StructKeys: Expected %v, got %v.", expected, actual)
+	}
+	// recursive and mutually embedded structs are expanded once
+	expected = []string{"weight", "name"}
+	if actual := StructKeys(suggestTestNode{}, "json"); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("StructKeys: Expected %v, got %v.", expected, actual)
+	}
+	if actual := StructKeys("not a struct", "json"); actual != nil {
+		t.Errorf("StructKeys: Expected nil, got %v.", actual)
+	}
+
+	suggestions := Suggest("loglevel", StructKeys(suggestTestConfig{}, "json"), nil)
+	if !reflect.DeepEqual(suggestions, []string{"log_level"}) {
+		t.Errorf("Suggest: Expected [log_level], got %v.", suggestions)
+	}
+}
@@ -0,0 +1,201 @@
+package fuzzy
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Synonyms is a dictionary mapping abbreviations and other variant
+// phrases to a canonical phrase, such as "St" to "street" or "NYC" to
+// "new york city". Canonicalizing both strings before comparison lets
+// the token scorers treat variants as the same tokens. Variants and
+// canonical phrases may span several tokens, and are matched
+// case-insensitively, ignoring trailing periods and commas.
+//
+// A *Synonyms can be set in TokenOptions for the token scorers to use,
+// its Canonicalize method can be used as a processor or Pipeline stage,
+// and Scorer adapts any other scorer, such as WRatio.
+type Synonyms struct {
+	phrases   map[string][]string
+	maxTokens int
+}
+
+// NewSynonyms creates an empty synonym dictionary.
+func NewSynonyms() *Synonyms {
+	return &Synonyms{phrases: make(map[string][]string)}
+}
+
+// Add registers variants of a canonical phrase.
+func (syn *Synonyms) Add(canonical string, variants ...string) {
+	canonicalTokens := strings.Fields(strings.ToLower(canonical))
+	for _, variant := range append(variants, canonical) {
+		tokens := strings.Fields(variant)
+		if len(tokens) == 0 {
+			continue
+		}
+		syn.phrases[phraseKey(tokens)] = canonicalTokens
+		if len(tokens) > syn.maxTokens {
+			syn.maxTokens = len(tokens)
+		}
+	}
+}
+
+// ReadSynonyms reads a synonym dictionary from r. Each line holds a
+// canonical phrase followed by its variants, separated by commas:
+//
+//	street, st, str
+//	new york city, nyc
+//
+// Blank lines and lines starting with # are ignored.
+func ReadSynonyms(r io.Reader) (*Synonyms, error) {
+	syn := NewSynonyms()
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		phrases := strings.Split(line, ",")
+		if len(phrases) < 2 {
+			return nil, fmt.Errorf("line %d: expecting a canonical phrase followed by variants", lineNo)
+		}
+		syn.Add(phrases[0], phrases[1:]...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return syn, nil
+}
+
+// DefaultAbbreviations returns a dictionary of common English company
+// name abbreviations, such as "Intl" and "Corp", and of a few place
+// names, such as "NYC". It leaves out abbreviations that are also
+// ordinary words or initials, such as "us", "co" or "e", so that it
+// can be used on names; AddressAbbreviations has those of addresses.
+func DefaultAbbreviations() *Synonyms {
+	return mustReadSynonyms(defaultAbbreviations)
+}
+
+// AddressAbbreviations returns a dictionary of common English street
+// address abbreviations, such as "St", "Dr" and "N", which is meant for
+// address fields only: on other text it also expands "St" for Saint,
+// "Dr" the title and initials such as the "E" of "John E Smith".
+func AddressAbbreviations() *Synonyms {
+	return mustReadSynonyms(addressAbbreviations)
+}
+
+func mustReadSynonyms(s string) *Synonyms {
+	syn, err := ReadSynonyms(strings.NewReader(s))
+	if err != nil {
+		panic(err)
+	}
+	return syn
+}
+
+// Canonicalize replaces every variant phrase among the whitespace-separated
+// tokens of s with its canonical phrase, preferring the longest variant at
+// each position, and joins the tokens with single spaces.
+func (syn *Synonyms) Canonicalize(s string) string {
+	return strings.Join(syn.canonicalizeTokens(strings.Fields(s)), " ")
+}
+
+// Scorer adapts a scorer so that both strings are canonicalized
+// before they are scored.
+func (syn *Synonyms) Scorer(scorer func(string, string) int) func(string, string) int {
+	return func(s1, s2 string) int {
+		return scorer(syn.Canonicalize(s1), syn.Canonicalize(s2))
+	}
+}
+
+func (syn *Synonyms) canonicalizeTokens(tokens []string) []string {
+	result := make([]string, 0, len(tokens))
+	for i := 0; i < len(tokens); {
+		matched := false
+		for n := min(syn.maxTokens, len(tokens)-i); n > 0; n-- {
+			if canonical, ok := syn.phrases[phraseKey(tokens[i:i+n])]; ok {
+				result = append(result, canonical...)
+				i += n
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			result = append(result, tokens[i])
+			i++
+		}
+	}
+	return result
+}
+
+func phraseKey(tokens []string) string {
+	keys := make([]string, len(tokens))
+	for i, token := range tokens {
+		keys[i] = strings.TrimRight(strings.ToLower(token), ".,")
+	}
+	return strings.Join(keys, " ")
+}
+
+const defaultAbbreviations = `
+# places
+new york city, nyc
+united states, usa, u.s, u.s.a
+united kingdom, uk, u.k
+# companies
+international, intl, int'l
+incorporated, inc
+corporation, corp
+limited, ltd
+limited liability company, llc, l.l.c
+public limited company, plc
+associates, assoc
+association, assn, assoc'n
+brothers, bros
+manufacturing, mfg
+department, dept
+university, univ
+institute, inst
+national, natl, nat'l
+services, svcs
+systems, sys
+holdings, hldgs
+group, grp
+`
+
+const addressAbbreviations = `
+street, st, str
+avenue, ave, av
+boulevard, blvd
+road, rd
+drive, dr
+lane, ln
+court, ct
+place, pl
+square, sq
+terrace, ter
+circle, cir
+plaza, plz
+parkway, pkwy
+highway, hwy
+expressway, expy
+freeway, fwy
+junction, jct
+heights, hts
+center, ctr, centre
+mount, mt
+fort, ft
+suite, ste
+apartment, apt
+building, bldg
+floor, fl
+room, rm
+north, n
+south, s
+east, e
+west, w
+northeast, ne
+northwest, nw
+southeast, se
+southwest, sw
+`
@@ -0,0 +1,70 @@
+package fuzzy
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCanonicalize(t *testing.T) {
+	syn := DefaultAbbreviations()
+	canonicalizeData := [][]string{
+		{"Acme Intl Inc", "Acme international incorporated"},
+		{"NYC  Ballet", "new york city Ballet"},
+		{"New York City Ballet", "new york city Ballet"},
+		{"U.S. Steel", "united states Steel"},
+		// ordinary words and initials are left alone
+		{"Tell us", "Tell us"},
+		{"John E Smith", "John E Smith"},
+		{"Dr Jones", "Dr Jones"},
+		{"St Louis Co Op", "St Louis Co Op"},
+		{"", ""},
+	}
+	for _, testCase := range canonicalizeData {
+		if actual := syn.Canonicalize(testCase[0]); actual != testCase[1] {
+			t.Errorf("Canonicalize %v: Expected %v, got %v.", testCase[0], testCase[1], actual)
+		}
+	}
+
+	address := AddressAbbreviations()
+	if actual := address.Canonicalize("123 N Main St."); actual != "123 north Main street" {
+		t.Errorf("Canonicalize: Expected %v, got %v.", "123 north Main street", actual)
+	}
+}
+
+func TestReadSynonyms(t *testing.T) {
+	syn, err := ReadSynonyms(strings.NewReader("# venues\nmadison square garden, msg, the garden\n\nstadium, stad\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := syn.Canonicalize("The Garden NY"); actual != "madison square garden NY" {
+		t.Errorf("Canonicalize: Expected %v, got %v.", "madison square garden NY", actual)
+	}
+	if actual := syn.Canonicalize("Yankee Stad."); actual != "Yankee stadium" {
+		t.Errorf("Canonicalize: Expected %v, got %v.", "Yankee stadium", actual)
+	}
+
+	if _, err := ReadSynonyms(strings.NewReader("stadium\n")); err == nil {
+		t.Error("Expected an error for a line without variants")
+	}
+}
+
+func TestSynonymsOption(t *testing.T) {
+	syn := DefaultAbbreviations()
+
+	s1, s2 := "Acme Intl Corp", "ACME International Corporation"
+	assertRatioIsNot100(t, "TokenSortRatio", s1, s2, TokenSortRatio(s1, s2, false, true))
+	assertRatioIs100(t, "TokenSortRatio", s1, s2, (&TokenOptions{Cleanse: true, Synonyms: syn}).TokenSortRatio(s1, s2))
+
+	syn.Add("and", "&")
+	s3, s4 := "Smith & Sons Ltd.", "smith and sons limited"
+	assertRatioIsNot100(t, "TokenSetRatio", s3, s4, TokenSetRatio(s3, s4, false, true))
+	assertRatioIs100(t, "TokenSetRatio", s3, s4, (&TokenOptions{Cleanse: true, Synonyms: syn}).TokenSetRatio(s3, s4))
+
+	s5, s6 := "NYC Ballet", "New York City Ballet"
+	assertRatioIsNot100(t, "WRatio", s5, s6, WRatio(s5, s6))
+	assertRatioIs100(t, "WRatio", s5, s6, syn.Scorer(WRatio)(s5, s6))
+
+	s7, s8 := "John E Smith", "John East Smith"
+	assertRatioIsNot100(t, "TokenSetRatio", s7, s8, (&TokenOptions{Cleanse: true, Synonyms: syn}).TokenSetRatio(s7, s8))
+	assertRatioIsNot100(t, "WRatio", s7, s8, syn.Scorer(WRatio)(s7, s8))
+}
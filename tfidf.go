@@ -0,0 +1,182 @@
+package fuzzy
+
+import (
+	"math"
+	"strings"
+)
+
+// Corpus learns how informative tokens are from a collection of documents,
+// typically the choices passed to Extract. Tokens occurring in many
+// documents, such as "inc" in a list of company names, get a low inverse
+// document frequency (IDF) weight, and rare tokens such as "anthropic" a
+// high one. The scorers of a Corpus weight tokens accordingly, so a shared
+// rare token counts for more than a shared common one.
+//
+// The scorers have the form f(string, string) -> int, so they can be passed
+// to Extract, ExtractOne and ExtractWithoutOrder:
+//
+//	corpus := NewCorpus(choices, nil)
+//	matches, err := Extract(query, choices, 5, corpus.TFIDFRatio)
+//
+// A Corpus may be used for scoring from several goroutines, as long as
+// no documents are added at the same time.
+type Corpus struct {
+	opts    *TokenOptions
+	docFreq map[string]int
+	docs    int
+}
+
+// NewCorpus creates a corpus from the given documents. Tokens are always
+// cleansed; the other options of opts, such as ASCIIOnly, a Tokenizer,
+// Synonyms and Stopwords, apply to every string the corpus tokenizes,
+// and nil uses the defaults.
+func NewCorpus(docs []string, opts *TokenOptions) *Corpus {
+	c := &Corpus{opts: opts.cleansed(), docFreq: make(map[string]int)}
+	for _, doc := range docs {
+		c.Add(doc)
+	}
+	return c
+}
+
+// Add adds a document to the corpus.
+func (c *Corpus) Add(doc string) {
+	for token := range c.termFreqs(doc) {
+		c.docFreq[token]++
+	}
+	c.docs++
+}
+
+// Len returns the number of documents in the corpus.
+func (c *Corpus) Len() int {
+	return c.docs
+}
+
+// IDF returns the smoothed inverse document frequency of token,
+// ln((1+n)/(1+df)) + 1, where n is the number of documents and df
+// the number of documents containing the token. Tokens absent from
+// the corpus get the highest weight.
+func (c *Corpus) IDF(token string) float64 {
+	return c.idf(strings.TrimSpace(c.opts.process(token)))
+}
+
+func (c *Corpus) idf(token string) float64 {
+	return math.Log(float64(1+c.docs)/float64(1+c.docFreq[token])) + 1
+}
+
+// TFIDFRatio computes the cosine similarity of the TF-IDF vectors of two
+// strings, in which each token is weighted by the number of times it occurs
+// in the string and by its IDF. Returns an integer score [0,100], higher
+// score indicates that strings share more, and more informative, tokens.
+func (c *Corpus) TFIDFRatio(s1, s2 string) int {
+	v1, v2 := c.vector(s1), c.vector(s2)
+	sim := 0.0
+	for token, w1 := range v1 {
+		sim += w1 * v2[token]
+	}
+	return similarityScore(sim)
+}
+
+// WeightedTokenSetRatio computes the weighted overlap of the token sets
+// of two strings: the total IDF of the tokens they share divided by the
+// total IDF of all their tokens.
+// Returns an integer score [0,100].
+func (c *Corpus) WeightedTokenSetRatio(s1, s2 string) int {
+	tf1, tf2 := c.termFreqs(s1), c.termFreqs(s2)
+	shared, total := 0.0, 0.0
+	for token := range tf1 {
+		idf := c.idf(token)
+		total += idf
+		if tf2[token] > 0 {
+			shared += idf
+		}
+	}
+	for token := range tf2 {
+		if tf1[token] == 0 {
+			total += c.idf(token)
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return similarityScore(shared / total)
+}
+
+// SoftTFIDFRatio computes a score similar to TFIDFRatio, except tokens
+// also match tokens spelled slightly differently, such as "anthropik"
+// and "anthropic", when their JaroWinklerRatio is at least 90 (see
+// SoftTFIDFScorer).
+func (c *Corpus) SoftTFIDFRatio(s1, s2 string) int {
+	return c.softTFIDF(s1, s2, JaroWinklerRatio, 90)
+}
+
+// SoftTFIDFScorer returns a soft TF-IDF scorer, which extends TFIDFRatio
+// by matching each token with the most similar token of the other string
+// under the given similarity function, such as Ratio or JaroWinklerRatio.
+// Tokens with a similarity of at least threshold contribute the product
+// of their TF-IDF weights, scaled by their similarity. The score is
+// computed in both directions and averaged, so the order of arguments
+// does not matter.
+func (c *Corpus) SoftTFIDFScorer(similarity func(string, string) int, threshold int) func(string, string) int {
+	return func(s1, s2 string) int {
+		return c.softTFIDF(s1, s2, similarity, threshold)
+	}
+}
+
+func (c *Corpus) softTFIDF(s1, s2 string, similarity func(string, string) int, threshold int) int {
+	v1, v2 := c.vector(s1), c.vector(s2)
+	sim := (softCosine(v1, v2, similarity, threshold) + softCosine(v2, v1, similarity, threshold)) / 2
+	return similarityScore(sim)
+}
+
+// softCosine sums, over the tokens of v1, the product of their weight,
+// the weight of the most similar token of v2 and their similarity.
+func softCosine(v1, v2 map[string]float64, similarity func(string, string) int, threshold int) float64 {
+	sim := 0.0
+	for token, w1 := range v1 {
+		if w2, ok := v2[token]; ok {
+			sim += w1 * w2
+			continue
+		}
+		best, bestToken := -1, ""
+		for other := range v2 {
+			if score := similarity(token, other); score > best || (score == best && other < bestToken) {
+				best, bestToken = score, other
+			}
+		}
+		if best >= threshold {
+			sim += w1 * v2[bestToken] * float64(best) / 100
+		}
+	}
+	return sim
+}
+
+// termFreqs counts the tokens of s.
+func (c *Corpus) termFreqs(s string) map[string]int {
+	tf := make(map[string]int)
+	for _, token := range c.opts.tokenize(s) {
+		tf[token]++
+	}
+	return tf
+}
+
+// vector computes the TF-IDF vector of s, normalized to unit length.
+func (c *Corpus) vector(s string) map[string]float64 {
+	v := make(map[string]float64)
+	norm := 0.0
+	for token, freq := range c.termFreqs(s) {
+		w := float64(freq) * c.idf(token)
+		v[token] = w
+		norm += w * w
+	}
+	norm = math.Sqrt(norm)
+	for token := range v {
+		v[token] /= norm
+	}
+	return v
+}
+
+// similarityScore converts a similarity in [0,1] to a score [0,100],
+// clamping rounding errors.
+func similarityScore(sim float64) int {
+	return int(round(100 * math.Max(0, math.Min(1, sim))))
+}
@@ -0,0 +1,83 @@
+package fuzzy
+
+import (
+	"testing"
+)
+
+var companies = []string{
+	"Anthropic Inc",
+	"Acme Inc",
+	"Globex Inc",
+	"Initech Inc",
+	"Acme Corporation",
+	"Umbrella Corporation",
+	"Hooli International Inc",
+}
+
+func TestCorpusIDF(t *testing.T) {
+	corpus := NewCorpus(companies, nil)
+	if corpus.Len() != len(companies) {
+		t.Errorf("Expected %v documents, got %v", len(companies), corpus.Len())
+	}
+	if corpus.IDF("Inc") >= corpus.IDF("anthropic") {
+		t.Error("Expected the common token inc to weigh less than anthropic")
+	}
+	if corpus.IDF("unseen") <= corpus.IDF("anthropic") {
+		t.Error("Expected unseen tokens to get the highest weight")
+	}
+}
+
+func TestTFIDFRatio(t *testing.T) {
+	corpus := NewCorpus(companies, nil)
+
+	assertRatioIs100(t, "TFIDFRatio", "Acme Inc", "ACME, Inc.", corpus.TFIDFRatio("Acme Inc", "ACME, Inc."))
+	assertRatio(t, "TFIDFRatio", "Acme Inc", "Umbrella Corporation", 0, corpus.TFIDFRatio("Acme Inc", "Umbrella Corporation"))
+	assertRatio(t, "TFIDFRatio", "", "Acme Inc", 0, corpus.TFIDFRatio("", "Acme Inc"))
+
+	// a shared "inc" counts for less than a shared "acme"
+	if corpus.TFIDFRatio("Acme Inc", "Globex Inc") >= corpus.TFIDFRatio("Acme Inc", "Acme Corporation") {
+		t.Error("Expected Acme Corporation to be closer to Acme Inc than Globex Inc")
+	}
+
+	match, err := ExtractOne("anthropic", companies, corpus.TFIDFRatio)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if match.Match != "Anthropic Inc" {
+		t.Errorf("Expected Anthropic Inc, got %v", match)
+	}
+}
+
+func TestWeightedTokenSetRatio(t *testing.T) {
+	corpus := NewCorpus(companies, nil)
+
+	assertRatioIs100(t, "WeightedTokenSetRatio", "Acme Inc", "inc acme", corpus.WeightedTokenSetRatio("Acme Inc", "inc acme"))
+	if corpus.WeightedTokenSetRatio("Acme Inc", "Globex Inc") >= corpus.WeightedTokenSetRatio("Acme Inc", "Acme Corporation") {
+		t.Error("Expected Acme Corporation to be closer to Acme Inc than Globex Inc")
+	}
+	assertRatio(t, "WeightedTokenSetRatio", "", "", 0, corpus.WeightedTokenSetRatio("", ""))
+}
+
+func TestSoftTFIDFRatio(t *testing.T) {
+	corpus := NewCorpus(companies, &TokenOptions{Synonyms: DefaultAbbreviations()})
+
+	s1, s2 := "Anthropik Inc", "Anthropic Inc"
+	assertRatioIsNot100(t, "SoftTFIDFRatio", s1, s2, corpus.SoftTFIDFRatio(s1, s2))
+	if corpus.SoftTFIDFRatio(s1, s2) <= corpus.TFIDFRatio(s1, s2) {
+		t.Error("Expected misspelled tokens to match under SoftTFIDFRatio")
+	}
+	assertRatio(t, "SoftTFIDFRatio", s2, s1, corpus.SoftTFIDFRatio(s1, s2), corpus.SoftTFIDFRatio(s2, s1))
+
+	// synonyms are applied before the tokens are weighted
+	assertRatioIs100(t, "SoftTFIDFRatio", "Hooli Intl Inc", "Hooli International Inc", corpus.SoftTFIDFRatio("Hooli Intl Inc", "Hooli International Inc"))
+
+	// without synonyms, only the Jaro-Winkler fallback matches the tokens
+	plain := NewCorpus(companies, nil)
+	assertRatio(t, "TFIDFRatio", "Anthropik", "Anthropic", 0, plain.TFIDFRatio("Anthropik", "Anthropic"))
+	if plain.SoftTFIDFRatio("Anthropik", "Anthropic") < 90 {
+		t.Errorf("Expected Anthropik to match Anthropic under SoftTFIDFRatio, got %v", plain.SoftTFIDFRatio("Anthropik", "Anthropic"))
+	}
+
+	strict := corpus.SoftTFIDFScorer(Ratio, 100)
+	assertRatio(t, "SoftTFIDFScorer", s1, s2, corpus.TFIDFRatio(s1, s2), strict(s1, s2))
+}
@@ -0,0 +1,224 @@
+package fuzzy
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Tokenizer splits a string into the tokens compared by the token scorers.
+// A Tokenizer can be set in TokenOptions to be used by TokenSortRatio,
+// TokenSetRatio and their partial versions.
+type Tokenizer interface {
+	Tokenize(s string) []string
+}
+
+// TokenizerFunc adapts an ordinary function to the Tokenizer interface.
+type TokenizerFunc func(string) []string
+
+// Tokenize calls f(s).
+func (f TokenizerFunc) Tokenize(s string) []string {
+	return f(s)
+}
+
+// WhitespaceTokenizer splits strings around runs of whitespace.
+// This is the tokenizer the token scorers use by default.
+var WhitespaceTokenizer Tokenizer = TokenizerFunc(strings.Fields)
+
+// WordTokenizer splits strings into words following the main rules of
+// the Unicode word boundary algorithm (UAX #29). Letters and digits are
+// grouped into words, apostrophes and periods inside words ("don't",
+// "U.S") and separators inside numbers ("1,000.50") do not split them,
+// and ideographs and hiragana, which are written without spaces, form
+// a word each. Punctuation and whitespace are dropped.
+var WordTokenizer Tokenizer = TokenizerFunc(splitWords)
+
+// IdentifierTokenizer splits identifiers and codes into their parts.
+// Tokens are separated at every rune that is not a letter or digit,
+// as in snake_case, kebab-case or "AB-1234/X", at lower to upper case
+// transitions, as in camelCase ("parseHTTPResponse" becomes "parse",
+// "HTTP", "Response"), and between letters and digits.
+var IdentifierTokenizer Tokenizer = TokenizerFunc(splitIdentifier)
+
+// NGramTokenizer returns a tokenizer producing the overlapping character
+// n-grams of a string. Strings shorter than n produce a single token.
+func NGramTokenizer(n int) Tokenizer {
+	return TokenizerFunc(func(s string) []string {
+		return nGrams(s, n)
+	})
+}
+
+// RegexpTokenizer returns a tokenizer whose tokens are the successive
+// non-overlapping matches of re.
+func RegexpTokenizer(re *regexp.Regexp) Tokenizer {
+	return TokenizerFunc(func(s string) []string {
+		return re.FindAllString(s, -1)
+	})
+}
+
+func nGrams(s string, n int) []string {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return []string{}
+	}
+	if n <= 0 || len(runes) <= n {
+		return []string{s}
+	}
+	grams := make([]string, 0, len(runes)-n+1)
+	for i := 0; i+n <= len(runes); i++ {
+		grams = append(grams, string(runes[i:i+n]))
+	}
+	return grams
+}
+
+type wordClass int
+
+const (
+	wordOther wordClass = iota
+	wordLetter
+	wordNumeric
+	wordKatakana
+	wordIdeographic
+	wordExtend
+	wordExtendNumLet
+	wordMidLetter
+	wordMidNum
+	wordMidNumLet
+)
+
+func classifyWordRune(r rune) wordClass {
+	switch {
+	case unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r):
+		return wordIdeographic
+	case unicode.Is(unicode.Katakana, r) || r == 'ー':
+		return wordKatakana
+	case unicode.IsLetter(r):
+		return wordLetter
+	case unicode.IsDigit(r):
+		return wordNumeric
+	case unicode.In(r, unicode.Mn, unicode.Mc, unicode.Me) || r == '\u200d':
+		return wordExtend
+	case r == '_' || unicode.Is(unicode.Pc, r):
+		return wordExtendNumLet
+	case r == ':' || r == '\u00b7' || r == '\u05f4' || r == '\u2027':
+		return wordMidLetter
+	case r == ',' || r == ';' || r == '\u037e' || r == '\u066c':
+		return wordMidNum
+	case r == '.' || r == '\'' || r == '\u2019' || r == '\u2024' || r == '\ufe52' || r == '\uff07' || r == '\uff0e':
+		return wordMidNumLet
+	}
+	return wordOther
+}
+
+func splitWords(s string) []string {
+	runes := []rune(s)
+	classes := make([]wordClass, len(runes))
+	for i, r := range runes {
+		classes[i] = classifyWordRune(r)
+	}
+
+	// base returns the class of the word character at or before i,
+	// skipping combining marks
+	base := func(i int) wordClass {
+		for i >= 0 && classes[i] == wordExtend {
+			i--
+		}
+		if i < 0 {
+			return wordOther
+		}
+		return classes[i]
+	}
+	// next returns the class of the first character after i that is
+	// not a combining mark
+	next := func(i int) wordClass {
+		for i++; i < len(runes) && classes[i] == wordExtend; i++ {
+		}
+		if i >= len(runes) {
+			return wordOther
+		}
+		return classes[i]
+	}
+
+	joins := func(i int) bool {
+		prev, cur := base(i-1), classes[i]
+		switch {
+		case cur == wordExtend:
+			return prev != wordOther
+		case prev == wordIdeographic || cur == wordIdeographic:
+			return false
+		case isWordBody(prev) && isWordBody(cur):
+			return prev == cur || prev == wordExtendNumLet || cur == wordExtendNumLet ||
+				(prev != wordKatakana && cur != wordKatakana)
+		case prev == wordLetter && (cur == wordMidLetter || cur == wordMidNumLet):
+			return next(i) == wordLetter
+		case prev == wordNumeric && (cur == wordMidNum || cur == wordMidNumLet):
+			return next(i) == wordNumeric
+		case cur == wordLetter && (prev == wordMidLetter || prev == wordMidNumLet):
+			return base(i-2) == wordLetter
+		case cur == wordNumeric && (prev == wordMidNum || prev == wordMidNumLet):
+			return base(i-2) == wordNumeric
+		}
+		return false
+	}
+
+	words := []string{}
+	start := 0
+	for i := 1; i <= len(runes); i++ {
+		if i < len(runes) && joins(i) {
+			continue
+		}
+		word := runes[start:i]
+		for _, c := range classes[start:i] {
+			if c == wordLetter || c == wordNumeric || c == wordKatakana || c == wordIdeographic {
+				words = append(words, string(word))
+				break
+			}
+		}
+		start = i
+	}
+	return words
+}
+
+func isWordBody(c wordClass) bool {
+	return c == wordLetter || c == wordNumeric || c == wordKatakana || c == wordExtendNumLet
+}
+
+func splitIdentifier(s string) []string {
+	tokens := []string{}
+	runes := []rune(s)
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				tokens = append(tokens, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start >= 0 && isIdentifierBoundary(runes, i) {
+			tokens = append(tokens, string(runes[start:i]))
+			start = i
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, string(runes[start:]))
+	}
+	return tokens
+}
+
+func isIdentifierBoundary(runes []rune, i int) bool {
+	prev, r := runes[i-1], runes[i]
+	switch {
+	case unicode.IsDigit(prev) != unicode.IsDigit(r):
+		return true
+	case unicode.IsLower(prev) && unicode.IsUpper(r):
+		return true
+	case unicode.IsUpper(prev) && unicode.IsUpper(r):
+		// the last capital of an acronym starts the next word: HTTPServer
+		return i+1 < len(runes) && unicode.IsLower(runes[i+1])
+	}
+	return false
+}
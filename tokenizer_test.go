@@ -0,0 +1,50 @@
+package fuzzy
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+var tokenizerData = []struct {
+	tokenizer Tokenizer
+	input     string
+	want      []string
+}{
+	{WhitespaceTokenizer, "  new york\tmets ", []string{"new", "york", "mets"}},
+	{WordTokenizer, "Don't stop, U.S. fans: $1,000.50!", []string{"Don't", "stop", "U.S", "fans", "1,000.50"}},
+	{WordTokenizer, "東京タワーへ行く", []string{"東", "京", "タワー", "へ", "行", "く"}},
+	{WordTokenizer, "snake_case résumé", []string{"snake_case", "résumé"}},
+	{IdentifierTokenizer, "parseHTTPResponse", []string{"parse", "HTTP", "Response"}},
+	{IdentifierTokenizer, "user_id-v2", []string{"user", "id", "v", "2"}},
+	{IdentifierTokenizer, "AB-1234/X", []string{"AB", "1234", "X"}},
+	{NGramTokenizer(3), "abcde", []string{"abc", "bcd", "cde"}},
+	{NGramTokenizer(3), "ab", []string{"ab"}},
+	{NGramTokenizer(2), "", []string{}},
+	{RegexpTokenizer(regexp.MustCompile(`[A-Z]+|\d+`)), "AB-1234/X", []string{"AB", "1234", "X"}},
+}
+
+func TestTokenizers(t *testing.T) {
+	for _, testCase := range tokenizerData {
+		actual := testCase.tokenizer.Tokenize(testCase.input)
+		if !reflect.DeepEqual(actual, testCase.want) {
+			t.Errorf("Tokenize %v: Expected %q, got %q.", testCase.input, testCase.want, actual)
+		}
+	}
+}
+
+func TestTokenizerOption(t *testing.T) {
+	s1, s2 := "getUserName", "user_name_get"
+	assertRatioIsNot100(t, "TokenSortRatio", s1, s2, TokenSortRatio(s1, s2, false, true))
+	assertRatioIs100(t, "TokenSortRatio", s1, s2, (&TokenOptions{Cleanse: true, Tokenizer: IdentifierTokenizer}).TokenSortRatio(s1, s2))
+	assertRatioIs100(t, "TokenSetRatio", s1, s2, (&TokenOptions{Cleanse: true, Tokenizer: IdentifierTokenizer}).TokenSetRatio(s1, s2))
+
+	s3, s4 := "東京タワー", "タワー東京"
+	assertRatioIsNot100(t, "TokenSetRatio", s3, s4, TokenSetRatio(s3, s4))
+	assertRatioIs100(t, "TokenSetRatio", s3, s4, (&TokenOptions{Tokenizer: WordTokenizer}).TokenSetRatio(s3, s4))
+	assertRatioIs100(t, "PartialTokenSortRatio", s3, s4, (&TokenOptions{Tokenizer: WordTokenizer}).PartialTokenSortRatio(s3, s4))
+
+	s5, s6 := "new york mets", "mets new york"
+	assertRatio(t, "TokenSortRatio", s5, s6,
+		TokenSortRatio(s5, s6, true, true), (&TokenOptions{ASCIIOnly: true, Cleanse: true, Tokenizer: WhitespaceTokenizer}).TokenSortRatio(s5, s6))
+}
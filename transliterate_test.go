@@ -0,0 +1,30 @@
+package fuzzy
+
+import (
+	"testing"
+)
+
+var transliterateData = [][]interface{}{
+	{"Beyoncé", "Beyonce"},
+	{"Zürich", "Zurich"},
+	{"Straße", "Strasse"},
+	{"Ærøskøbing", "AEroskobing"},
+	{"Łódź", "Lodz"},
+	{"Nguyễn", "Nguyen"},
+	{"Чайковский", "Chaykovskiy"},
+	{"Αθήνα", "Athina"},
+	{"été", "ete"},
+	{"“quoted” – text", "\"quoted\" - text"},
+	{"你好", "你好"},
+}
+
+func TestTransliterate(t *testing.T) {
+	for _, testCase := range transliterateData {
+		actual := Transliterate(testCase[0].(string))
+		expected := testCase[1]
+		if actual != expected {
+			t.Errorf("Transliterate %v: Expected %v, got %v.",
+				testCase[0], expected, actual)
+		}
+	}
+}